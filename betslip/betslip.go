@@ -0,0 +1,256 @@
+package betslip
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+)
+
+// Line is a single bet of a bet slip
+type Line struct {
+	Number      int         `json:"-"`
+	SelectionID string      `json:"selection_id"`
+	Stake       money.Money `json:"stake"` // A number, or a string with a currency code, e.g. "10.50 GBP"
+	Odds        float64     `json:"odds"`  // Odds taken by the bettor, 0 accepts the feed price
+	Currency    string      `json:"-"`     // Currency of the whole slip, taken from its first line
+}
+
+// Line statuses after checking against the prematch markets
+const (
+	StatusAccepted = "ACCEPTED"
+	StatusRejected = "REJECTED"
+)
+
+// oddsTolerance absorbs rounding when comparing taken odds with feed odds
+const oddsTolerance = 0.005
+
+// Report records what happened to a slip line
+type Report struct {
+	Line     Line
+	Status   string
+	Messages []string
+}
+
+// Found pairs a slip line with the offered selection it refers to
+type Found[S any] struct {
+	Selection S
+	Report    Report
+}
+
+// Load reads a bet slip from a .json or .csv file
+func Load(filename string) ([]Line, error) {
+	log.Printf("Loading bet slip from %s", filename)
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bet slip file: %v", err)
+	}
+	defer file.Close()
+
+	var lines []Line
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		lines, err = parseJSON(file)
+	case ".csv":
+		lines, err = parseCSV(file)
+	default:
+		return nil, fmt.Errorf("unsupported bet slip format: %s", filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing bet slip: %v", err)
+	}
+
+	setCurrency(lines)
+	log.Printf("Successfully loaded bet slip, found %d lines", len(lines))
+	return lines, nil
+}
+
+// setCurrency gives every line the currency of the first line's stake. Totals
+// cannot add amounts in different currencies, so Check rejects a line staked
+// in any other; an unlabelled stake is in the default currency.
+func setCurrency(lines []Line) {
+	if len(lines) == 0 {
+		return
+	}
+	currency := lines[0].Stake.Currency().Code
+	for i := range lines {
+		lines[i].Currency = currency
+	}
+}
+
+// parseJSON accepts either a bare array of lines or an object with a "bets" array
+func parseJSON(r io.Reader) ([]Line, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []Line
+	if err := json.Unmarshal(content, &lines); err != nil {
+		var wrapped struct {
+			Bets []Line `json:"bets"`
+		}
+		if err := json.Unmarshal(content, &wrapped); err != nil {
+			return nil, err
+		}
+		lines = wrapped.Bets
+	}
+
+	for i := range lines {
+		lines[i].Number = i + 1
+	}
+	return lines, nil
+}
+
+// parseCSV reads lines with a "selection_id,stake,odds" header; odds may be left empty
+func parseCSV(r io.Reader) ([]Line, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return []Line{}, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"selection_id", "stake"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	lines := []Line{}
+	for i, record := range records[1:] {
+		line := Line{Number: i + 1, SelectionID: field(record, "selection_id")}
+		if line.Stake, err = money.Parse(field(record, "stake")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid stake %q", line.Number, field(record, "stake"))
+		}
+		if odds := field(record, "odds"); odds != "" {
+			if line.Odds, err = strconv.ParseFloat(odds, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid odds %q", line.Number, odds)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// Match looks up each line's selection ID among the offered selections. Bet365
+// parent rows ("PC" prefix) label the priced row with the same number, so a
+// line pointing at one is resolved to that row. Lines not found are returned
+// so they can be tried against the next event.
+func Match[S any](lines []Line, offered []S, selectionID func(S) string) ([]Found[S], []Line) {
+	index := make(map[string]S)
+	for _, selection := range offered {
+		index[selectionID(selection)] = selection
+	}
+
+	found := []Found[S]{}
+	remaining := []Line{}
+	for _, line := range lines {
+		report := Report{Line: line, Status: StatusAccepted}
+		selection, ok := index[line.SelectionID]
+		if !ok && strings.HasPrefix(line.SelectionID, "PC") {
+			priced := strings.TrimPrefix(line.SelectionID, "PC")
+			if selection, ok = index[priced]; ok {
+				report.Messages = append(report.Messages,
+					fmt.Sprintf("%s is a market header row, settled as selection %s", line.SelectionID, priced))
+			}
+		}
+		if !ok {
+			remaining = append(remaining, line)
+			continue
+		}
+		found = append(found, Found[S]{Selection: selection, Report: report})
+	}
+	return found, remaining
+}
+
+// Check validates a matched line against the offered price and market status.
+// It returns the odds the line settles at: the taken odds, or the feed price
+// when none were given or the taken odds are above it. Closed markets,
+// non-positive stakes and stakes in another currency than the rest of the
+// slip are rejected; odds mismatches are reported.
+func (r *Report) Check(offeredOdds float64, closed bool) float64 {
+	if closed {
+		r.reject("market is closed (open: 0)")
+	}
+	if r.Line.Stake.Sign() <= 0 {
+		r.reject(fmt.Sprintf("invalid stake %s", r.Line.Stake))
+	}
+	if code := r.Line.Stake.Currency().Code; r.Line.Currency != "" && code != r.Line.Currency {
+		r.reject(fmt.Sprintf("stake is in %s but the slip is in %s; every line must use one currency",
+			code, r.Line.Currency))
+	}
+
+	if r.Line.Odds == 0 {
+		return offeredOdds
+	}
+	if math.Abs(r.Line.Odds-offeredOdds) <= oddsTolerance {
+		return r.Line.Odds
+	}
+	// A price above the feed was never offered, so the line is paid at the feed price
+	if r.Line.Odds > offeredOdds {
+		r.Messages = append(r.Messages,
+			fmt.Sprintf("odds mismatch: taken %.2f, feed %.2f; settled at feed odds", r.Line.Odds, offeredOdds))
+		return offeredOdds
+	}
+	r.Messages = append(r.Messages,
+		fmt.Sprintf("odds mismatch: taken %.2f, feed %.2f; settled at taken odds", r.Line.Odds, offeredOdds))
+	return r.Line.Odds
+}
+
+func (r *Report) reject(message string) {
+	r.Status = StatusRejected
+	r.Messages = append(r.Messages, message)
+}
+
+// Unknown reports lines whose selection ID was not offered by any event
+func Unknown(lines []Line) []Report {
+	reports := []Report{}
+	for _, line := range lines {
+		reports = append(reports, Report{
+			Line:     line,
+			Status:   StatusRejected,
+			Messages: []string{"unknown selection ID"},
+		})
+	}
+	return reports
+}
+
+// PrintReports prints one row per slip line that was rejected or needs attention
+func PrintReports(reports []Report) {
+	issues := []Report{}
+	for _, report := range reports {
+		if report.Status != StatusAccepted || len(report.Messages) > 0 {
+			issues = append(issues, report)
+		}
+	}
+	if len(issues) == 0 {
+		return
+	}
+
+	fmt.Println("\n===================== BET SLIP ISSUES =====================")
+	for _, report := range issues {
+		fmt.Printf("Line %d (%s): %s - %s\n", report.Line.Number, report.Line.SelectionID,
+			report.Status, strings.Join(report.Messages, "; "))
+	}
+}
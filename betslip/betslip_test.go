@@ -0,0 +1,51 @@
+package betslip
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+)
+
+func TestReportCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		stake    string
+		currency string
+		taken    float64
+		offered  float64
+		closed   bool
+		want     float64
+		status   string
+		messages int
+	}{
+		{"feed price when none taken", "10", "USD", 0, 1.83, false, 1.83, StatusAccepted, 0},
+		{"taken odds within tolerance", "10", "USD", 1.834, 1.83, false, 1.834, StatusAccepted, 0},
+		{"taken odds below the feed", "10", "USD", 1.80, 1.83, false, 1.80, StatusAccepted, 1},
+		{"taken odds above the feed", "10", "USD", 1.90, 1.83, false, 1.83, StatusAccepted, 1},
+		{"closed market", "10", "USD", 1.83, 1.83, true, 1.83, StatusRejected, 1},
+		{"zero stake", "0", "USD", 1.83, 1.83, false, 1.83, StatusRejected, 1},
+		{"stake in another currency", "10 GBP", "USD", 1.83, 1.83, false, 1.83, StatusRejected, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stake, err := money.Parse(test.stake)
+			if err != nil {
+				t.Fatalf("money.Parse(%q): %v", test.stake, err)
+			}
+			report := Report{
+				Line:   Line{SelectionID: "1", Stake: stake, Odds: test.taken, Currency: test.currency},
+				Status: StatusAccepted,
+			}
+			if got := report.Check(test.offered, test.closed); got != test.want {
+				t.Errorf("Check settled at %.3f, want %.3f", got, test.want)
+			}
+			if report.Status != test.status {
+				t.Errorf("status %s, want %s", report.Status, test.status)
+			}
+			if len(report.Messages) != test.messages {
+				t.Errorf("messages %q, want %d", report.Messages, test.messages)
+			}
+		})
+	}
+}
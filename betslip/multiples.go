@@ -0,0 +1,354 @@
+package betslip
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// Multiple is a bet combining several legs, e.g. a double or a Yankee. Stake
+// is the stake of every line, so a Yankee at 1.00 costs 11.00.
+type Multiple struct {
+	Number int         `json:"-"`
+	Type   string      `json:"type"`
+	Stake  money.Money `json:"stake"`
+	Legs   []Line      `json:"legs"` // Stake is copied from the multiple; Number is unique across the file
+}
+
+// Leg is a multiple leg settled by the market processors of its sport
+type Leg struct {
+	Number      int
+	SelectionID string
+	Event       string // Sport and event ID the leg was offered by, e.g. "cricket 9703206"
+	Market      string
+	Description string
+	Odds        float64
+	Price       odds.Odds // Ladder fraction of the odds, used to settle returns exactly
+	Outcome     settlement.Outcome
+	Evaluation  string
+	Report      Report
+}
+
+// MultipleLine is one combination of legs of a multiple
+type MultipleLine struct {
+	Legs    []Leg
+	Odds    float64 // Product of the legs that stand; void and pushed legs count as 1.00
+	Outcome settlement.Outcome
+	Return  money.Money
+}
+
+// MultipleResult is a settled multiple. Rejected multiples have no lines.
+type MultipleResult struct {
+	Multiple Multiple
+	Status   string
+	Messages []string
+	Legs     []Leg
+	Lines    []MultipleLine
+	Stake    money.Money
+	Returns  money.Money
+}
+
+// multipleType describes how a multiple type combines its legs
+type multipleType struct {
+	name    string
+	minLegs int
+	maxLegs int               // 0 for any number of legs
+	sizes   func(n int) []int // Number of legs in each line for n legs
+}
+
+// multipleTypes are keyed by lower-case name without spaces, dashes or underscores
+var multipleTypes = map[string]multipleType{
+	"single":      {"Singles", 1, 0, sizesBetween(1, 1)},
+	"double":      {"Doubles", 2, 0, sizesBetween(2, 2)},
+	"treble":      {"Trebles", 3, 0, sizesBetween(3, 3)},
+	"accumulator": {"Accumulator", 2, 0, func(n int) []int { return []int{n} }},
+	"trixie":      {"Trixie", 3, 3, sizesBetween(2, 3)},
+	"patent":      {"Patent", 3, 3, sizesBetween(1, 3)},
+	"yankee":      {"Yankee", 4, 4, sizesBetween(2, 4)},
+	"lucky15":     {"Lucky 15", 4, 4, sizesBetween(1, 4)},
+	"heinz":       {"Heinz", 6, 6, sizesBetween(2, 6)},
+}
+
+// multipleAliases maps other common names to a multiple type
+var multipleAliases = map[string]string{
+	"singles":  "single",
+	"doubles":  "double",
+	"trebles":  "treble",
+	"acca":     "accumulator",
+	"parlay":   "accumulator",
+	"multiple": "accumulator",
+}
+
+// sizesBetween builds lines of every size from from to to legs
+func sizesBetween(from, to int) func(n int) []int {
+	return func(int) []int {
+		sizes := []int{}
+		for size := from; size <= to; size++ {
+			sizes = append(sizes, size)
+		}
+		return sizes
+	}
+}
+
+// LoadMultiples reads multiples from a JSON file holding a "multiples" array
+func LoadMultiples(filename string) ([]Multiple, error) {
+	log.Printf("Loading multiples from %s", filename)
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading multiples file: %v", err)
+	}
+
+	var data struct {
+		Multiples []Multiple `json:"multiples"`
+	}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("error unmarshaling multiples: %v", err)
+	}
+
+	leg := 0
+	for i := range data.Multiples {
+		data.Multiples[i].Number = i + 1
+		for j := range data.Multiples[i].Legs {
+			leg++
+			data.Multiples[i].Legs[j].Number = leg
+			data.Multiples[i].Legs[j].Stake = data.Multiples[i].Stake
+		}
+	}
+	legs := LegLines(data.Multiples)
+	setCurrency(legs)
+	if len(legs) > 0 {
+		for i := range data.Multiples {
+			for j := range data.Multiples[i].Legs {
+				data.Multiples[i].Legs[j].Currency = legs[0].Currency
+			}
+		}
+	}
+
+	log.Printf("Successfully loaded %d multiples with %d legs", len(data.Multiples), leg)
+	return data.Multiples, nil
+}
+
+// LegLines returns the legs of every multiple as slip lines, so each sport can
+// settle the legs it offers
+func LegLines(multiples []Multiple) []Line {
+	lines := []Line{}
+	for _, multiple := range multiples {
+		lines = append(lines, multiple.Legs...)
+	}
+	return lines
+}
+
+// lookupType finds a multiple type by name, e.g. "Lucky 15" or "acca"
+func lookupType(name string) (multipleType, bool) {
+	key := strings.ToLower(name)
+	for _, r := range []string{" ", "-", "_"} {
+		key = strings.ReplaceAll(key, r, "")
+	}
+	if alias, ok := multipleAliases[key]; ok {
+		key = alias
+	}
+	kind, ok := multipleTypes[key]
+	return kind, ok
+}
+
+// SettleMultiple settles a multiple from its settled legs, keyed by leg
+// number. The multiple is rejected when its type or number of legs is
+// invalid, when any leg was rejected or not offered by any event, or when two
+// legs are related selections from the same market of an event. Void and
+// pushed legs are dropped from their lines, and a line whose legs are all
+// dropped returns its stake.
+func SettleMultiple(multiple Multiple, settled map[int]Leg) MultipleResult {
+	result := MultipleResult{Multiple: multiple, Status: StatusAccepted}
+
+	kind, ok := lookupType(multiple.Type)
+	n := len(multiple.Legs)
+	switch {
+	case !ok:
+		result.reject(fmt.Sprintf("unknown multiple type %q", multiple.Type))
+	case kind.maxLegs > 0 && n != kind.maxLegs:
+		result.reject(fmt.Sprintf("a %s needs %d legs, got %d", kind.name, kind.maxLegs, n))
+	case n < kind.minLegs:
+		result.reject(fmt.Sprintf("%s: at least %d legs needed, got %d", kind.name, kind.minLegs, n))
+	case multiple.Stake.Sign() <= 0:
+		result.reject(fmt.Sprintf("invalid stake %s", multiple.Stake))
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range multiple.Legs {
+		if seen[line.SelectionID] {
+			result.reject(fmt.Sprintf("selection %s is used in more than one leg", line.SelectionID))
+		}
+		seen[line.SelectionID] = true
+
+		leg, ok := settled[line.Number]
+		if !ok {
+			result.reject(fmt.Sprintf("leg %s: unknown selection ID", line.SelectionID))
+			continue
+		}
+		if leg.Report.Status != StatusAccepted {
+			result.reject(fmt.Sprintf("leg %s: %s", line.SelectionID, strings.Join(leg.Report.Messages, "; ")))
+		}
+		for _, other := range result.Legs {
+			if related(leg, other) {
+				result.reject(fmt.Sprintf("legs %s and %s are from the same market of one event; related selections cannot be combined",
+					other.SelectionID, leg.SelectionID))
+			}
+		}
+		result.Legs = append(result.Legs, leg)
+	}
+	if result.Status != StatusAccepted {
+		return result
+	}
+
+	for _, size := range kind.sizes(n) {
+		for _, combination := range combinations(n, size) {
+			legs := []Leg{}
+			for _, i := range combination {
+				legs = append(legs, result.Legs[i])
+			}
+			line := settleLine(legs, multiple.Stake)
+			result.Lines = append(result.Lines, line)
+			result.Stake = result.Stake.Add(multiple.Stake)
+			result.Returns = result.Returns.Add(line.Return)
+		}
+	}
+	return result
+}
+
+func (r *MultipleResult) reject(message string) {
+	r.Status = StatusRejected
+	r.Messages = append(r.Messages, message)
+}
+
+// related reports whether two legs are selections of the same market of an
+// event, whose results depend on each other
+func related(a, b Leg) bool {
+	return a.Event != "" && a.Event == b.Event && a.Market == b.Market
+}
+
+// settleLine multiplies the return per unit staked of each leg. A losing leg
+// loses the line; half wins, half losses and dead heats reduce its return.
+func settleLine(legs []Leg, stake money.Money) MultipleLine {
+	price := big.NewRat(1, 1)
+	perUnit := big.NewRat(1, 1)
+	dropped := 0
+	for _, leg := range legs {
+		perUnit.Mul(perUnit, leg.Outcome.ReturnPerUnit(leg.Price))
+		if leg.Outcome.Refunded() {
+			dropped++
+		} else {
+			price.Mul(price, settlement.WinOrLose(true).ReturnPerUnit(leg.Price))
+		}
+	}
+
+	line := MultipleLine{Legs: legs}
+	line.Odds, _ = price.Float64()
+	switch {
+	case perUnit.Sign() == 0:
+		line.Outcome = settlement.Outcome{Status: settlement.Lose}
+	case dropped == len(legs):
+		line.Outcome = settlement.Outcome{Status: settlement.Void}
+	default:
+		line.Outcome = settlement.Outcome{Status: settlement.Win}
+	}
+	line.Return = stake.Mul(perUnit, money.DefaultRounding)
+	return line
+}
+
+// combinations lists every way of choosing size indexes out of n, in order
+func combinations(n, size int) [][]int {
+	all := [][]int{}
+	var pick func(start int, chosen []int)
+	pick = func(start int, chosen []int) {
+		if len(chosen) == size {
+			all = append(all, append([]int{}, chosen...))
+			return
+		}
+		for i := start; i < n; i++ {
+			pick(i+1, append(chosen, i))
+		}
+	}
+	pick(0, []int{})
+	return all
+}
+
+// lineName names a line by its number of legs, e.g. "Double" or "5-Fold"
+func lineName(legs int) string {
+	switch legs {
+	case 1:
+		return "Single"
+	case 2:
+		return "Double"
+	case 3:
+		return "Treble"
+	}
+	return fmt.Sprintf("%d-Fold", legs)
+}
+
+// PrintMultiples prints every multiple with its legs and the return of each line
+func PrintMultiples(results []MultipleResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	fmt.Println("\n======================== MULTIPLES ========================")
+	summary := settlement.NewSummary()
+	for _, result := range results {
+		multiple := result.Multiple
+		if result.Status != StatusAccepted {
+			fmt.Printf("\nMultiple %d (%s): %s - %s\n", multiple.Number, multiple.Type,
+				result.Status, strings.Join(result.Messages, "; "))
+			continue
+		}
+
+		kind, _ := lookupType(multiple.Type)
+		lines := "lines"
+		if len(result.Lines) == 1 {
+			lines = "line"
+		}
+		fmt.Printf("\nMultiple %d: %s, %d legs, %d %s x %s = %s\n",
+			multiple.Number, kind.name, len(result.Legs), len(result.Lines), lines, multiple.Stake, result.Stake)
+		for i, leg := range result.Legs {
+			note := ""
+			if leg.Outcome.Refunded() {
+				note = " (dropped from its lines)"
+			}
+			fmt.Printf("  Leg %d: %s @ %.2f - %s%s\n", i+1, leg.Description, leg.Odds, leg.Outcome, note)
+			if leg.Evaluation != "" {
+				fmt.Printf("         %s\n", leg.Evaluation)
+			}
+			for _, message := range leg.Report.Messages {
+				fmt.Printf("         Note: %s\n", message)
+			}
+		}
+
+		for _, line := range result.Lines {
+			prices := []string{}
+			for _, leg := range line.Legs {
+				if leg.Outcome.Refunded() {
+					prices = append(prices, "void")
+				} else {
+					prices = append(prices, fmt.Sprintf("%.2f", leg.Odds))
+				}
+			}
+			fmt.Printf("  %-7s %s = %.2f - %s, return %s\n", lineName(len(line.Legs)),
+				strings.Join(prices, " x "), line.Odds, line.Outcome, line.Return)
+			summary.Add(line.Outcome, multiple.Stake, line.Return)
+		}
+		fmt.Printf("  Total Return: %s (Profit/Loss: %s)\n", result.Returns, result.Returns.Sub(result.Stake))
+	}
+
+	fmt.Println("\n==================== MULTIPLES SUMMARY ====================")
+	fmt.Printf("Lines: %d (%s)\n", summary.Bets, summary.Breakdown())
+	fmt.Printf("Total Stake: %s\n", summary.Stake)
+	fmt.Printf("Total Returns: %s\n", summary.Returns)
+	fmt.Printf("Profit/Loss: %s\n", summary.ProfitLoss())
+	fmt.Printf("ROI: %.2f%%\n", summary.ROI())
+}
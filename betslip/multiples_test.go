@@ -0,0 +1,225 @@
+package betslip
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// testLeg is an accepted leg at num/den settled with the given outcome
+func testLeg(t *testing.T, num, den int64, outcome settlement.Outcome) Leg {
+	t.Helper()
+	price, err := odds.New(num, den)
+	if err != nil {
+		t.Fatalf("odds.New(%d, %d): %v", num, den, err)
+	}
+	return Leg{Odds: price.Decimal(), Price: price, Outcome: outcome, Report: Report{Status: StatusAccepted}}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		n, size int
+		want    [][]int
+	}{
+		{3, 1, [][]int{{0}, {1}, {2}}},
+		{3, 2, [][]int{{0, 1}, {0, 2}, {1, 2}}},
+		{3, 3, [][]int{{0, 1, 2}}},
+		{4, 3, [][]int{{0, 1, 2}, {0, 1, 3}, {0, 2, 3}, {1, 2, 3}}},
+		{2, 3, [][]int{}},
+	}
+
+	for _, test := range tests {
+		if got := combinations(test.n, test.size); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("combinations(%d, %d) = %v, want %v", test.n, test.size, got, test.want)
+		}
+	}
+}
+
+func TestMultipleTypeLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		legs  int
+		sizes []int
+		lines int
+	}{
+		{"single", 3, []int{1}, 3},
+		{"doubles", 3, []int{2}, 3},
+		{"Treble", 4, []int{3}, 4},
+		{"acca", 5, []int{5}, 1},
+		{"Trixie", 3, []int{2, 3}, 4},
+		{"Patent", 3, []int{1, 2, 3}, 7},
+		{"Yankee", 4, []int{2, 3, 4}, 11},
+		{"Lucky 15", 4, []int{1, 2, 3, 4}, 15},
+		{"lucky-15", 4, []int{1, 2, 3, 4}, 15},
+		{"Heinz", 6, []int{2, 3, 4, 5, 6}, 57},
+	}
+
+	for _, test := range tests {
+		kind, ok := lookupType(test.name)
+		if !ok {
+			t.Errorf("lookupType(%q) found no type", test.name)
+			continue
+		}
+		sizes := kind.sizes(test.legs)
+		lines := 0
+		for _, size := range sizes {
+			lines += len(combinations(test.legs, size))
+		}
+		if !reflect.DeepEqual(sizes, test.sizes) || lines != test.lines {
+			t.Errorf("%s of %d legs = sizes %v, %d lines, want %v, %d lines",
+				test.name, test.legs, sizes, lines, test.sizes, test.lines)
+		}
+	}
+}
+
+func TestSettleLine(t *testing.T) {
+	win := settlement.WinOrLose(true)
+	lose := settlement.WinOrLose(false)
+	void := settlement.Outcome{Status: settlement.Void}
+	push := settlement.Outcome{Status: settlement.Push}
+
+	tests := []struct {
+		name   string
+		legs   func(t *testing.T) []Leg
+		odds   float64
+		status settlement.Status
+		ret    string
+	}{
+		{"every leg wins", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 5, 6, win), testLeg(t, 1, 1, win)}
+		}, 3.67, settlement.Win, "$36.66"},
+		{"losing leg keeps the combined price", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 5, 6, win), testLeg(t, 1, 1, lose)}
+		}, 3.67, settlement.Lose, "$0.00"},
+		{"void leg is dropped", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 5, 6, win), testLeg(t, 1, 1, void)}
+		}, 1.83, settlement.Win, "$18.33"},
+		{"every leg dropped", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 5, 6, void), testLeg(t, 1, 1, push)}
+		}, 1.00, settlement.Void, "$10.00"},
+		{"half win", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 1, 1, settlement.Outcome{Status: settlement.HalfWin}), testLeg(t, 5, 6, win)}
+		}, 3.67, settlement.Win, "$27.50"},
+		{"half lose", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 1, 1, settlement.Outcome{Status: settlement.HalfLose}), testLeg(t, 1, 1, win)}
+		}, 4.00, settlement.Win, "$10.00"},
+		{"dead heat", func(t *testing.T) []Leg {
+			return []Leg{testLeg(t, 4, 1, settlement.DeadHeatFor(2, 1)), testLeg(t, 1, 1, win)}
+		}, 10.00, settlement.Win, "$50.00"},
+	}
+
+	stake, _ := money.Parse("10")
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			line := settleLine(test.legs(t), stake)
+			if math.Abs(line.Odds-test.odds) > 0.005 || line.Outcome.Status != test.status || line.Return.String() != test.ret {
+				t.Errorf("line = %.2f %s, return %s, want %.2f %s, return %s",
+					line.Odds, line.Outcome, line.Return, test.odds, test.status, test.ret)
+			}
+		})
+	}
+}
+
+func TestSettleMultipleRejects(t *testing.T) {
+	win := settlement.WinOrLose(true)
+	stake, _ := money.Parse("1")
+
+	// leg builds a slip line with its settled leg from an event and market
+	leg := func(number int, id, event, market string) (Line, Leg) {
+		settled := testLeg(t, 1, 1, win)
+		settled.Number, settled.SelectionID, settled.Event, settled.Market = number, id, event, market
+		return Line{Number: number, SelectionID: id, Stake: stake}, settled
+	}
+
+	tests := []struct {
+		name    string
+		kind    string
+		stake   string
+		legs    [][3]string // Selection ID, event and market of each leg
+		unknown int         // Leg number left out of the settled legs, 0 for none
+		message string      // Part of the rejection, empty when the multiple stands
+	}{
+		{"legs from different events", "double", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 0, ""},
+		{"different markets of one event", "double", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "cricket 1", "Top Batter"}}, 0, ""},
+		{"same market of one event", "Trixie", "1",
+			[][3]string{{"1", "volleyball 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}, {"3", "cricket 1", "Match Winner"}}, 0,
+			"legs 1 and 2 are from the same market of one event"},
+		{"repeated selection", "double", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"1", "volleyball 1", "Match Winner"}}, 0,
+			"selection 1 is used in more than one leg"},
+		{"unknown type", "round robin", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 0,
+			`unknown multiple type "round robin"`},
+		{"wrong number of legs", "Yankee", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 0,
+			"a Yankee needs 4 legs, got 2"},
+		{"too few legs", "treble", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 0,
+			"at least 3 legs needed, got 2"},
+		{"zero stake", "double", "0",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 0,
+			"invalid stake"},
+		{"leg not offered", "double", "1",
+			[][3]string{{"1", "cricket 1", "Match Winner"}, {"2", "volleyball 1", "Match Winner"}}, 2,
+			"leg 2: unknown selection ID"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			multipleStake, err := money.Parse(test.stake)
+			if err != nil {
+				t.Fatalf("money.Parse(%q): %v", test.stake, err)
+			}
+			multiple := Multiple{Type: test.kind, Stake: multipleStake}
+			settled := make(map[int]Leg)
+			for i, spec := range test.legs {
+				line, settledLeg := leg(i+1, spec[0], spec[1], spec[2])
+				multiple.Legs = append(multiple.Legs, line)
+				if line.Number != test.unknown {
+					settled[line.Number] = settledLeg
+				}
+			}
+
+			result := SettleMultiple(multiple, settled)
+			messages := strings.Join(result.Messages, "; ")
+			if test.message == "" {
+				if result.Status != StatusAccepted || len(result.Lines) == 0 {
+					t.Errorf("multiple %s with %d lines (%s), want accepted", result.Status, len(result.Lines), messages)
+				}
+				return
+			}
+			if result.Status != StatusRejected || !strings.Contains(messages, test.message) {
+				t.Errorf("multiple %s (%s), want rejected with %q", result.Status, messages, test.message)
+			}
+			if len(result.Lines) != 0 {
+				t.Errorf("rejected multiple has %d lines", len(result.Lines))
+			}
+		})
+	}
+}
+
+func TestSettleMultipleRejectedLeg(t *testing.T) {
+	stake, _ := money.Parse("1")
+	multiple := Multiple{Type: "double", Stake: stake, Legs: []Line{
+		{Number: 1, SelectionID: "1", Stake: stake},
+		{Number: 2, SelectionID: "2", Stake: stake},
+	}}
+	closed := testLeg(t, 1, 1, settlement.Outcome{})
+	closed.SelectionID = "2"
+	closed.Report = Report{Status: StatusRejected, Messages: []string{"market is closed (open: 0)"}}
+	settled := map[int]Leg{1: testLeg(t, 1, 1, settlement.WinOrLose(true)), 2: closed}
+
+	result := SettleMultiple(multiple, settled)
+	want := "leg 2: market is closed (open: 0)"
+	if result.Status != StatusRejected || !slices.Contains(result.Messages, want) {
+		t.Errorf("multiple %s %q, want rejected with %q", result.Status, result.Messages, want)
+	}
+}
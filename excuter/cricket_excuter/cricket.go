@@ -2,75 +2,57 @@ package cricket_excuter
 
 import (
 	"log"
-	"math/rand"
 	"time"
 
+	"github.com/yesetoda/bet365-evaluator-go/betslip"
 	"github.com/yesetoda/bet365-evaluator-go/helpers/cricket_helper"
 	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/pricing"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
 )
 
 func CricketExecutor() {
-	// Set up logging to file
-
-	// Seed the random number generator for simulated data
-	rand.Seed(time.Now().UnixNano())
-
-	// Load the JSON data from files
-	resultData, err := cricket_helper.LoadCricketResultData("data/cricket_result.json")
+	// Load the feeds and join every prematch entry to its result
+	events, unmatched, err := cricket_helper.LoadEvents("data")
 	if err != nil {
-		log.Fatalf("Failed to load result data: %v", err)
+		log.Fatalf("Failed to load cricket data: %v", err)
 	}
 
-	prematchData, err := cricket_helper.LoadCricketPrematchData("data/cricket_prematch.json")
+	// Load the bet slip to settle
+	lines, err := betslip.Load("data/cricket_betslip.csv")
 	if err != nil {
-		log.Fatalf("Failed to load prematch data: %v", err)
+		log.Fatalf("Failed to load bet slip: %v", err)
+	}
+
+	// Report each event separately
+	for _, event := range events {
+		lines = evaluateEvent(event.Prematch, event.Result, event.Scorecard, event.Deliveries, lines)
 	}
 
+	betslip.PrintReports(betslip.Unknown(lines))
+	cricket_helper.PrintUnmatchedEvents(unmatched)
+
+	log.Println("Completed cricket betting evaluation at", time.Now().Format(time.RFC1123))
+}
+
+// evaluateEvent prints the evaluation report of a single event and returns
+// the slip lines that were not offered by it
+func evaluateEvent(prematch cricket.CricketPrematchResult, result cricket.CricketMatchResult, scorecard *cricket.Scorecard, deliveries *cricket.MatchDeliveries, lines []betslip.Line) []betslip.Line {
 	// Extract and process the match information
-	matchInfo := cricket_helper.ExtractDetailedMatchInfo(resultData)
+	matchInfo := cricket_helper.ExtractDetailedMatchInfo(result, scorecard, deliveries)
 
 	// Print detailed match information
 	cricket_helper.PrintMatchHeader(matchInfo)
 
-
-
 	// Print extended match statistics
 	cricket_helper.PrintDetailedMatchStats(matchInfo)
 
-	// Create a list of bet selections and evaluate them
-	betSelections := []cricket.BetSelection{}
-
-	// Market 1: Match Winner (To Win the Match)
-	winnerSelection := cricket_helper.CreateMatchWinnerSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, winnerSelection)
-
-	// Market 2: First Over Total Runs
-	firstOverRunsSelection := cricket_helper.CreateFirstOverRunsSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, firstOverRunsSelection)
-
-	// Market 3: 1st Innings Score
-	inningsScoreSelection := cricket_helper.CreateFirstInningsScoreSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, inningsScoreSelection)
-
-	// Market 4: A Fifty to be Scored
-	fiftySelection := cricket_helper.CreateFiftyToBeScored(prematchData, matchInfo)
-	betSelections = append(betSelections, fiftySelection)
-
-	// Market 5: Super Over
-	superOverSelection := cricket_helper.CreateSuperOverSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, superOverSelection)
-
-	// Market 6: Most Match Sixes
-	mostSixesSelection := cricket_helper.CreateMostSixesSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, mostSixesSelection)
-
-	// Market 7: Most Match Fours
-	mostFoursSelection := cricket_helper.CreateMostFoursSelection(prematchData, matchInfo)
-	betSelections = append(betSelections, mostFoursSelection)
-
-	// Market 8: A Hundred to be Scored
-	hundredSelection := cricket_helper.CreateHundredToBeScored(prematchData, matchInfo)
-	betSelections = append(betSelections, hundredSelection)
+	// Stake the slip lines offered by this event and settle them
+	input := cricket.MarketInput{Prematch: prematch, MatchInfo: matchInfo}
+	offered := cricket_helper.CreateBetSelections(input)
+	betSelections, reports, remaining := cricket_helper.ApplyBetSlip(lines, offered)
+	betSelections = cricket_helper.EvaluateBetSelections(betSelections, matchInfo)
 
 	// Display evaluation results
 	cricket_helper.PrintBettingEvaluationHeader()
@@ -80,21 +62,16 @@ func CricketExecutor() {
 	}
 
 	// Overall summary and additional metrics
-	wins := 0
-	totalStake := 100.0 // Assuming equal stakes for simplicity
-	totalReturns := 0.0
-
+	summary := settlement.NewSummary()
 	for _, bet := range betSelections {
-		if bet.IsWinner {
-			wins++
-			totalReturns += totalStake * bet.Odds
-		}
+		summary.Add(bet.Outcome, bet.StakeAmount, bet.Outcome.Return(bet.StakeAmount, bet.Price))
 	}
 
-	profitLoss := totalReturns - (totalStake * float64(len(betSelections)))
-	roi := (profitLoss / (totalStake * float64(len(betSelections)))) * 100
+	cricket_helper.PrintBettingEvaluationSummary(summary)
+	betslip.PrintReports(reports)
+	pricing.PrintMargins(cricket_helper.MarketMargins(prematch, matchInfo))
+	odds.PrintOffLadder(cricket_helper.LadderQuotes(prematch, matchInfo))
+	cricket_helper.PrintUnsupportedMarkets(cricket_helper.UnsupportedMarkets(prematch))
 
-	cricket_helper.PrintBettingEvaluationSummary(wins, len(betSelections), totalStake, totalReturns, profitLoss, roi)
-
-	log.Println("Completed cricket betting evaluation at", time.Now().Format(time.RFC1123))
+	return remaining
 }
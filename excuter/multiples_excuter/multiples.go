@@ -0,0 +1,88 @@
+package multiples_excuter
+
+import (
+	"log"
+
+	"github.com/yesetoda/bet365-evaluator-go/betslip"
+	"github.com/yesetoda/bet365-evaluator-go/helpers/cricket_helper"
+	"github.com/yesetoda/bet365-evaluator-go/helpers/volleyball_helper"
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+)
+
+// MultiplesExecutor settles the multiples in data/multiples.json. Their legs
+// may be taken from any cricket or volleyball event of the feeds.
+func MultiplesExecutor() {
+	multiples, err := betslip.LoadMultiples("data/multiples.json")
+	if err != nil {
+		log.Fatalf("Failed to load multiples: %v", err)
+	}
+
+	lines := betslip.LegLines(multiples)
+	cricketLegs, lines := settleCricketLegs(lines)
+	volleyballLegs, lines := settleVolleyballLegs(lines)
+
+	settled := make(map[int]betslip.Leg)
+	for _, leg := range append(cricketLegs, volleyballLegs...) {
+		settled[leg.Number] = leg
+	}
+	if len(lines) > 0 {
+		log.Printf("%d multiple legs were not offered by any event", len(lines))
+	}
+
+	results := []betslip.MultipleResult{}
+	for _, multiple := range multiples {
+		results = append(results, betslip.SettleMultiple(multiple, settled))
+	}
+	betslip.PrintMultiples(results)
+}
+
+// settleCricketLegs settles the legs offered by the cricket events
+func settleCricketLegs(lines []betslip.Line) ([]betslip.Leg, []betslip.Line) {
+	events, _, err := cricket_helper.LoadEvents("data")
+	if err != nil {
+		log.Fatalf("Failed to load cricket data: %v", err)
+	}
+
+	legs := []betslip.Leg{}
+	for _, event := range events {
+		matchInfo := cricket_helper.ExtractDetailedMatchInfo(event.Result, event.Scorecard, event.Deliveries)
+
+		offered := cricket_helper.CreateBetSelections(cricket.MarketInput{Prematch: event.Prematch, MatchInfo: matchInfo})
+		eventLegs, remaining := cricket_helper.SettleLegs(lines, offered, matchInfo)
+		for i := range eventLegs {
+			eventLegs[i].Event = "cricket " + event.Result.ID
+		}
+		legs = append(legs, eventLegs...)
+		lines = remaining
+	}
+
+	return legs, lines
+}
+
+// settleVolleyballLegs settles the legs offered by the volleyball events
+func settleVolleyballLegs(lines []betslip.Line) ([]betslip.Leg, []betslip.Line) {
+	prematchData, err := volleyball_helper.LoadVolleyballPrematchData("data/volleyball_prematch.json")
+	if err != nil {
+		log.Fatalf("Failed to load prematch data: %v", err)
+	}
+
+	resultData, err := volleyball_helper.LoadVolleyballResultData("data/volleyball_result.json")
+	if err != nil {
+		log.Fatalf("Failed to load result data: %v", err)
+	}
+
+	legs := []betslip.Leg{}
+	events, _ := volleyball_helper.PairEvents(prematchData, resultData)
+	for _, event := range events {
+		offered := volleyball_helper.CreateBetSelections(&event.Prematch)
+		matchStats := volleyball_helper.CalculateMatchStatistics(&event.Result)
+		eventLegs, remaining := volleyball_helper.SettleLegs(lines, offered, &event.Result, matchStats)
+		for i := range eventLegs {
+			eventLegs[i].Event = "volleyball " + event.Result.ID
+		}
+		legs = append(legs, eventLegs...)
+		lines = remaining
+	}
+
+	return legs, lines
+}
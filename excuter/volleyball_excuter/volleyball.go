@@ -1,18 +1,26 @@
 package volleyball_excuter
+
 import (
 	"log"
 	"os"
 
+	"github.com/yesetoda/bet365-evaluator-go/betslip"
 	"github.com/yesetoda/bet365-evaluator-go/helpers/volleyball_helper"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/pricing"
 )
 
 func VolleyballExecutor() {
 	// Check if file paths are provided as command-line arguments
 	var prematchFilePath, resultFilePath string
+	betSlipFilePath := "data/volleyball_betslip.json"
 
 	if len(os.Args) > 2 {
 		prematchFilePath = os.Args[1]
 		resultFilePath = os.Args[2]
+		if len(os.Args) > 3 {
+			betSlipFilePath = os.Args[3]
+		}
 	} else {
 		// Default file paths
 		prematchFilePath = "data/volleyball_prematch.json"
@@ -31,18 +39,36 @@ func VolleyballExecutor() {
 		log.Fatalf("Failed to load result data: %v", err)
 	}
 
-	// Simulate stake amount for each bet
-	stakeAmount := 100.0 // Default stake amount of $100
+	// Load the bet slip to settle
+	lines, err := betslip.Load(betSlipFilePath)
+	if err != nil {
+		log.Fatalf("Failed to load bet slip: %v", err)
+	}
+
+	// Join every prematch entry to its result and report each event separately
+	events, unmatched := volleyball_helper.PairEvents(prematchData, resultData)
+	for _, event := range events {
+		// Stake the slip lines offered by this event
+		offered := volleyball_helper.CreateBetSelections(&event.Prematch)
+		selections, reports, remaining := volleyball_helper.ApplyBetSlip(lines, offered)
+		lines = remaining
+
+		// Calculate match statistics
+		matchStats := volleyball_helper.CalculateMatchStatistics(&event.Result)
 
-	// Create bet selections
-	selections := volleyball_helper.CreateBetSelections(prematchData, stakeAmount)
+		// Evaluate bet selections
+		evaluations := volleyball_helper.EvaluateBetSelections(selections, &event.Result, matchStats)
 
-	// Calculate match statistics
-	matchStats := volleyball_helper.CalculateMatchStatistics(resultData)
+		// Display results
+		volleyball_helper.DisplayResults(evaluations, &event.Result, matchStats)
+		betslip.PrintReports(reports)
 
-	// Evaluate bet selections
-	evaluations := volleyball_helper.EvaluateBetSelections(selections, resultData, matchStats)
+		// Compare the game lines with prices derived from the correct set score
+		volleyball_helper.PrintFairPrices(volleyball_helper.PriceMarkets(&event.Prematch), &event.Result, matchStats)
+		pricing.PrintMargins(volleyball_helper.MarketMargins(&event.Prematch, &event.Result))
+		odds.PrintOffLadder(volleyball_helper.LadderQuotes(&event.Prematch, &event.Result))
+	}
 
-	// Display results
-	volleyball_helper.DisplayResults(evaluations, resultData, matchStats)
+	betslip.PrintReports(betslip.Unknown(lines))
+	volleyball_helper.PrintUnmatchedEvents(unmatched)
 }
@@ -0,0 +1,64 @@
+package feed
+
+// EventKey identifies an event by its Bet365 fixture ID and BetsAPI event ID.
+// Prematch entries carry them as FI/event_id, results as bet365_id/id.
+type EventKey struct {
+	Bet365ID string
+	EventID  string
+}
+
+// Pair joins the prematch entry of an event to its result
+type Pair[P, R any] struct {
+	Key      EventKey
+	Prematch P
+	Result   R
+}
+
+// Unmatched describes an event present on only one side of the feed
+type Unmatched struct {
+	Key    EventKey
+	Source string // "prematch" or "result"
+}
+
+// Join pairs prematch entries with result entries, first by Bet365 fixture
+// ID and then by event ID. Pairs keep the order of the prematch feed; events
+// without a counterpart are returned as unmatched instead of being dropped.
+func Join[P, R any](prematch []P, prematchKey func(P) EventKey, results []R, resultKey func(R) EventKey) ([]Pair[P, R], []Unmatched) {
+	byBet365ID := make(map[string]int)
+	byEventID := make(map[string]int)
+	for i, result := range results {
+		key := resultKey(result)
+		if _, exists := byBet365ID[key.Bet365ID]; key.Bet365ID != "" && !exists {
+			byBet365ID[key.Bet365ID] = i
+		}
+		if _, exists := byEventID[key.EventID]; key.EventID != "" && !exists {
+			byEventID[key.EventID] = i
+		}
+	}
+
+	pairs := []Pair[P, R]{}
+	unmatched := []Unmatched{}
+	used := make(map[int]bool)
+
+	for _, entry := range prematch {
+		key := prematchKey(entry)
+		index, ok := byBet365ID[key.Bet365ID]
+		if !ok || used[index] {
+			index, ok = byEventID[key.EventID]
+		}
+		if !ok || used[index] {
+			unmatched = append(unmatched, Unmatched{Key: key, Source: "prematch"})
+			continue
+		}
+		used[index] = true
+		pairs = append(pairs, Pair[P, R]{Key: key, Prematch: entry, Result: results[index]})
+	}
+
+	for i, result := range results {
+		if !used[i] {
+			unmatched = append(unmatched, Unmatched{Key: resultKey(result), Source: "result"})
+		}
+	}
+
+	return pairs, unmatched
+}
@@ -0,0 +1,213 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func init() {
+	Markets.Register(batterLineMarket{
+		id:          "300331",
+		name:        "Batter Match Runs",
+		description: "Bet on whether a batter will score over or under a number of runs in the match",
+		stat:        "runs",
+		market:      func(p cricket.CricketPrematchResult) cricket.Market { return p.Player.SP.BatterMatchRuns },
+		value:       func(stats cricket.BattingStats) int { return stats.Runs },
+	})
+	Markets.Register(batterLineMarket{
+		id:          "300345",
+		name:        "Batter Total Match Fours",
+		description: "Bet on whether a batter will hit over or under a number of fours in the match",
+		stat:        "fours",
+		market:      anyMarket("batter_total_match_fours"),
+		value:       func(stats cricket.BattingStats) int { return stats.Boundaries },
+	})
+	Markets.Register(batterLineMarket{
+		id:          "300344",
+		name:        "Batter Total Match Sixes",
+		description: "Bet on whether a batter will hit over or under a number of sixes in the match",
+		stat:        "sixes",
+		market:      anyMarket("batter_total_match_sixes"),
+		value:       func(stats cricket.BattingStats) int { return stats.Sixes },
+	})
+	Markets.Register(batterMilestoneMarket{
+		id:          "300388",
+		name:        "Batter Milestones",
+		description: "Bet on a batter reaching a number of runs in the match, e.g. 30+",
+		market:      func(p cricket.CricketPrematchResult) cricket.Market { return p.Player.SP.BatterMilestones },
+	})
+	Markets.Register(batterMilestoneMarket{
+		id:          "30216",
+		name:        "Batter to Score a Fifty in the Match",
+		description: "Bet on whether a batter will score fifty or more runs in the match",
+		market:      anyMarket("batter_to_score_a_fifty_in_the_match"),
+		runs:        50,
+	})
+	Markets.Register(batterMilestoneMarket{
+		id:          "30217",
+		name:        "Batter to Score a Hundred in the Match",
+		description: "Bet on whether a batter will score a hundred or more runs in the match",
+		market:      anyMarket("batter_to_score_a_hundred_in_the_match"),
+		runs:        100,
+	})
+}
+
+// anyMarket reads a market from whichever section of the prematch odds prices it
+func anyMarket(key string) func(p cricket.CricketPrematchResult) cricket.Market {
+	return func(p cricket.CricketPrematchResult) cricket.Market {
+		market, _ := p.Market(key)
+		return market
+	}
+}
+
+// batterName labels a batter option with the batter's team, e.g. "N Rana (Rajasthan Royals)"
+func batterName(odd cricket.Odd, matchInfo cricket.DetailedMatchInfo) string {
+	team := teamOptionName(odd.Name2, matchInfo, "")
+	if team == "" {
+		return odd.Name
+	}
+	return fmt.Sprintf("%s (%s)", odd.Name, team)
+}
+
+// batted returns the match figures of the batter of a selection. Under the
+// standard rules the selection is void when the batter was not in the
+// playing XI, or was in it but did not bat.
+func batted(selection *cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) (cricket.BattingStats, bool) {
+	if !hasScorecard(selection, matchInfo) {
+		return cricket.BattingStats{}, false
+	}
+	player := selection.Option.Name
+	if matchInfo.Scorecard.TeamOf(player) == "" {
+		voidSelection(selection, fmt.Sprintf("%s was not in the playing XI", player))
+		return cricket.BattingStats{}, false
+	}
+	stats, ok := matchInfo.BattingStats[player]
+	if !ok {
+		voidSelection(selection, fmt.Sprintf("%s was in the playing XI for %s but did not bat",
+			player, matchInfo.Scorecard.TeamOf(player)))
+		return cricket.BattingStats{}, false
+	}
+	return stats, true
+}
+
+// batterLineMarket settles an Over/Under market on a batter's match total,
+// e.g. "Batter Match Runs". Each option carries the batter as name, the team
+// as name2 and the line as handicap.
+type batterLineMarket struct {
+	id          string
+	name        string
+	description string
+	stat        string // Unit of the total used in the evaluation, e.g. "runs"
+	market      func(p cricket.CricketPrematchResult) cricket.Market
+	value       func(stats cricket.BattingStats) int
+}
+
+func (b batterLineMarket) MarketID() string { return b.id }
+
+func (b batterLineMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            b.name,
+		MarketID:          b.id,
+		MarketDescription: b.description,
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, b.market(input.Prematch),
+		func(odd cricket.Odd) string { return odd.Name + " " + overUnderOption(odd) },
+		func(odd cricket.Odd) string {
+			return fmt.Sprintf("%s %s %s", batterName(odd, input.MatchInfo), overUnderOption(odd), b.stat)
+		})
+}
+
+func (b batterLineMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	stats, ok := batted(&selection, matchInfo)
+	if !ok {
+		return selection
+	}
+	actual := b.value(stats)
+
+	// Without a result the total stands only once the line was passed
+	line, settled := settleTotal(&selection, actual, matchInfo.Outcome.Decided(), matchInfo.Outcome.String())
+	if settled {
+		selection.Evaluation = fmt.Sprintf("%s had %d %s (%s %.1f)",
+			selection.Option.Name, actual, b.stat, compareToLine(actual, line), line)
+	}
+
+	return selection
+}
+
+// batterMilestoneMarket settles a market on a batter reaching a number of
+// runs. "Batter Milestones" names the milestone in the header, e.g.
+// "10+ Runs", and only prices the batter to reach it; the fifty and hundred
+// markets have a fixed milestone and price Yes and No.
+type batterMilestoneMarket struct {
+	id          string
+	name        string
+	description string
+	market      func(p cricket.CricketPrematchResult) cricket.Market
+	runs        int // Fixed milestone; 0 when the header names it
+}
+
+func (b batterMilestoneMarket) MarketID() string { return b.id }
+
+// milestone returns the runs an option needs and whether it backs the batter to reach them
+func (b batterMilestoneMarket) milestone(odd cricket.Odd) (int, bool) {
+	if b.runs > 0 {
+		return b.runs, odd.Header != "No"
+	}
+	runs, _ := strconv.Atoi(strings.TrimSpace(strings.SplitN(odd.Header, "+", 2)[0]))
+	return runs, true
+}
+
+func (b batterMilestoneMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            b.name,
+		MarketID:          b.id,
+		MarketDescription: b.description,
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, b.market(input.Prematch),
+		func(odd cricket.Odd) string { return odd.Name + " " + odd.Header },
+		func(odd cricket.Odd) string {
+			runs, reach := b.milestone(odd)
+			if !reach {
+				return fmt.Sprintf("%s not to score %d+ runs", batterName(odd, input.MatchInfo), runs)
+			}
+			return fmt.Sprintf("%s to score %d+ runs", batterName(odd, input.MatchInfo), runs)
+		})
+}
+
+func (b batterMilestoneMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	stats, ok := batted(&selection, matchInfo)
+	if !ok {
+		return selection
+	}
+	runs, reach := b.milestone(selection.Option)
+	if runs <= 0 {
+		voidSelection(&selection, fmt.Sprintf("Unknown milestone %q", selection.Option.Header))
+		return selection
+	}
+
+	// A milestone reached before the match was cut short stands; one not yet
+	// reached could still have been, so the selection is void
+	reached := stats.Runs >= runs
+	if !reached && !matchInfo.Outcome.Decided() {
+		voidSelection(&selection, fmt.Sprintf("%s, with %s on %d of the %d runs needed",
+			matchInfo.Outcome, selection.Option.Name, stats.Runs, runs))
+		return selection
+	}
+
+	selection.Outcome = settlement.WinOrLose(reached == reach)
+	if reached {
+		selection.Evaluation = fmt.Sprintf("%s scored %d runs, reaching %d+", selection.Option.Name, stats.Runs, runs)
+	} else {
+		selection.Evaluation = fmt.Sprintf("%s scored %d runs, short of %d+", selection.Option.Name, stats.Runs, runs)
+	}
+
+	return selection
+}
@@ -0,0 +1,106 @@
+package cricket_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// batterMatch is the ranking match with boundaries for A1 (40 runs, four
+// fours and a six) and A3 (10 runs), ending with the given result. B2 is in
+// the XI of A but did not bat.
+func batterMatch(result cricket.ResultType) cricket.DetailedMatchInfo {
+	matchInfo := rankingMatch()
+	matchInfo.BattingStats["A1"] = cricket.BattingStats{Team: "H", Runs: 40, Boundaries: 4, Sixes: 1}
+	matchInfo.BattingStats["A3"] = cricket.BattingStats{Team: "H", Runs: 10, Boundaries: 1}
+	matchInfo.Outcome = cricket.MatchOutcome{Type: result}
+	return matchInfo
+}
+
+func TestBatterLineMarkets(t *testing.T) {
+	tests := []struct {
+		name     string
+		marketID string
+		player   string
+		side     string
+		line     string
+		result   cricket.ResultType
+		want     settlement.Outcome
+	}{
+		{"runs over", "300331", "A1", "Over", "35.5", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"runs under", "300331", "A1", "Under", "35.5", cricket.ResultWin, settlement.WinOrLose(false)},
+		{"runs on a whole line", "300331", "A1", "Over", "40", cricket.ResultWin, settlement.Outcome{Status: settlement.Push}},
+		{"fours over", "300345", "A1", "Over", "3.5", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"fours under", "300345", "A3", "Under", "1.5", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"sixes over", "300344", "A1", "Over", "1.5", cricket.ResultWin, settlement.WinOrLose(false)},
+		{"sixes under", "300344", "A1", "Under", "1.5", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"line passed before no result", "300331", "A1", "Over", "25.5", cricket.ResultNoResult, settlement.WinOrLose(true)},
+		{"line not reached before no result", "300331", "A3", "Under", "25.5", cricket.ResultNoResult, settlement.Outcome{Status: settlement.Void}},
+		{"not in the XI", "300331", "C1", "Over", "25.5", cricket.ResultWin, settlement.Outcome{Status: settlement.Void}},
+		{"in the XI but did not bat", "300345", "B2", "Under", "0.5", cricket.ResultWin, settlement.Outcome{Status: settlement.Void}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := cricket.BetSelection{Option: cricket.Odd{Name: test.player, Header: test.side, Handicap: test.line}}
+			got, err := Markets.Evaluate(test.marketID, selection, batterMatch(test.result))
+			if err != nil {
+				t.Fatalf("Evaluate(%s): %v", test.marketID, err)
+			}
+			if got.Outcome != test.want {
+				t.Errorf("%s %s %s settled %s (%s), want %s", test.player, test.side, test.line, got.Outcome, got.Evaluation, test.want)
+			}
+		})
+	}
+}
+
+func TestBatterMilestoneMarkets(t *testing.T) {
+	tests := []struct {
+		name     string
+		marketID string
+		player   string
+		header   string
+		result   cricket.ResultType
+		want     settlement.Outcome
+	}{
+		{"milestone reached", "300388", "A1", "30+ Runs", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"milestone reached exactly", "300388", "A1", "40+ Runs", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"milestone missed", "300388", "A3", "30+ Runs", cricket.ResultWin, settlement.WinOrLose(false)},
+		{"fifty missed", "30216", "A1", "Yes", cricket.ResultWin, settlement.WinOrLose(false)},
+		{"no fifty", "30216", "A1", "No", cricket.ResultWin, settlement.WinOrLose(true)},
+		{"no hundred after a draw", "30217", "A1", "No", cricket.ResultDraw, settlement.WinOrLose(true)},
+		{"reached before no result", "300388", "A1", "30+ Runs", cricket.ResultNoResult, settlement.WinOrLose(true)},
+		{"not out short of it at no result", "300388", "A3", "30+ Runs", cricket.ResultNoResult, settlement.Outcome{Status: settlement.Void}},
+		{"not out short of a fifty at abandonment", "30216", "A1", "No", cricket.ResultAbandoned, settlement.Outcome{Status: settlement.Void}},
+		{"unknown milestone", "300388", "A1", "Runs", cricket.ResultWin, settlement.Outcome{Status: settlement.Void}},
+		{"not in the XI", "300388", "C1", "10+ Runs", cricket.ResultWin, settlement.Outcome{Status: settlement.Void}},
+		{"in the XI but did not bat", "30216", "B2", "No", cricket.ResultWin, settlement.Outcome{Status: settlement.Void}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := cricket.BetSelection{Option: cricket.Odd{Name: test.player, Header: test.header}}
+			got, err := Markets.Evaluate(test.marketID, selection, batterMatch(test.result))
+			if err != nil {
+				t.Fatalf("Evaluate(%s): %v", test.marketID, err)
+			}
+			if got.Outcome != test.want {
+				t.Errorf("%s %s settled %s (%s), want %s", test.player, test.header, got.Outcome, got.Evaluation, test.want)
+			}
+		})
+	}
+}
+
+func TestBatterWithoutScorecard(t *testing.T) {
+	for _, marketID := range []string{"300331", "300388"} {
+		selection := cricket.BetSelection{Option: cricket.Odd{Name: "A1", Header: "Over", Handicap: "25.5"}}
+		got, err := Markets.Evaluate(marketID, selection, cricket.DetailedMatchInfo{})
+		if err != nil {
+			t.Fatalf("Evaluate(%s): %v", marketID, err)
+		}
+		if got.Outcome.Status != settlement.Void {
+			t.Errorf("market %s settled %s without a scorecard, want %s", marketID, got.Outcome, settlement.Void)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package cricket_helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+)
+
+// LoadCricketDeliveryData loads cricket ball-by-ball data from a JSON file
+func LoadCricketDeliveryData(filename string) (cricket.CricketDeliveryData, error) {
+	var data cricket.CricketDeliveryData
+
+	log.Printf("Loading ball-by-ball data from %s", filename)
+	fileContent, err := os.ReadFile(filename)
+	if err != nil {
+		return data, fmt.Errorf("error reading ball-by-ball file: %v", err)
+	}
+
+	if err := json.Unmarshal(fileContent, &data); err != nil {
+		return data, fmt.Errorf("error unmarshaling ball-by-ball data: %v", err)
+	}
+
+	log.Printf("Successfully loaded ball-by-ball data, found %d matches", len(data.Results))
+	return data, nil
+}
+
+// FindDeliveries returns the deliveries of a result by bet365_id or id, or nil if there are none
+func FindDeliveries(data cricket.CricketDeliveryData, result cricket.CricketMatchResult) *cricket.MatchDeliveries {
+	for i, match := range data.Results {
+		if (match.Bet365ID != "" && match.Bet365ID == result.Bet365ID) ||
+			(match.ID != "" && match.ID == result.ID) {
+			return &data.Results[i]
+		}
+	}
+	return nil
+}
+
+// DeriveScorecard builds the scorecard of a match from its deliveries. Team
+// sheets and the player of the match are copied from sheets when given, so
+// batters who did not bat can be listed.
+func DeriveScorecard(match cricket.MatchDeliveries, sheets *cricket.Scorecard) cricket.Scorecard {
+	scorecard := cricket.Scorecard{ID: match.ID, Bet365ID: match.Bet365ID}
+	if sheets != nil {
+		scorecard.Home = sheets.Home
+		scorecard.Away = sheets.Away
+		scorecard.PlayerOfTheMatch = sheets.PlayerOfTheMatch
+		scorecard.Status = sheets.Status
+		scorecard.ScheduledOvers = sheets.ScheduledOvers
+	}
+
+	for i, innings := range match.Innings {
+		card := deriveInnings(innings, scorecard)
+		if sheets != nil && i < len(sheets.Innings) && sheets.Innings[i].Number == card.Number {
+			// Interruptions are not recorded ball by ball
+			card.MaxOvers = sheets.Innings[i].MaxOvers
+			card.Target = sheets.Innings[i].Target
+		}
+		scorecard.Innings = append(scorecard.Innings, card)
+	}
+	return scorecard
+}
+
+// deriveInnings totals the deliveries of one innings into its scorecard
+func deriveInnings(innings cricket.InningsDeliveries, sheets cricket.Scorecard) cricket.Innings {
+	card := cricket.Innings{
+		Number:      innings.Number,
+		BattingTeam: innings.BattingTeam,
+		BowlingTeam: innings.BowlingTeam,
+		SuperOver:   innings.SuperOver,
+	}
+
+	batterIndex := make(map[string]int)
+	bowlerIndex := make(map[string]int)
+	bowlerBalls := make(map[string]int)
+	overConceded := make(map[string]map[int]int) // Runs charged to the bowler per over
+	overBalls := make(map[string]map[int]int)
+	legalBalls := 0
+
+	addBatter := func(name string) {
+		if _, ok := batterIndex[name]; !ok {
+			batterIndex[name] = len(card.Batters)
+			card.Batters = append(card.Batters, cricket.BatterScore{Name: name, Dismissal: "not out"})
+		}
+	}
+
+	for _, delivery := range innings.Deliveries {
+		addBatter(delivery.Batter)
+		addBatter(delivery.NonStriker)
+		if _, ok := bowlerIndex[delivery.Bowler]; !ok {
+			bowlerIndex[delivery.Bowler] = len(card.Bowlers)
+			card.Bowlers = append(card.Bowlers, cricket.BowlerFigures{Name: delivery.Bowler})
+			overConceded[delivery.Bowler] = make(map[int]int)
+			overBalls[delivery.Bowler] = make(map[int]int)
+		}
+
+		for len(card.OverRuns) < delivery.Over {
+			card.OverRuns = append(card.OverRuns, 0)
+		}
+		card.OverRuns[delivery.Over-1] += delivery.Total()
+		card.Runs += delivery.Total()
+
+		batter := &card.Batters[batterIndex[delivery.Batter]]
+		batter.Runs += delivery.Runs
+		if delivery.FacedByBatter() {
+			batter.Balls++
+		}
+		if delivery.Runs == 4 {
+			batter.Fours++
+		} else if delivery.Runs == 6 {
+			batter.Sixes++
+		}
+
+		bowler := &card.Bowlers[bowlerIndex[delivery.Bowler]]
+		conceded := delivery.Runs
+		switch delivery.ExtraType {
+		case cricket.ExtraWide:
+			card.Extras.Wides += delivery.Extras
+			bowler.Wides += delivery.Extras
+			conceded += delivery.Extras
+		case cricket.ExtraNoBall:
+			card.Extras.NoBalls += delivery.Extras
+			bowler.NoBalls += delivery.Extras
+			conceded += delivery.Extras
+		case cricket.ExtraBye:
+			card.Extras.Byes += delivery.Extras
+		case cricket.ExtraLegBye:
+			card.Extras.LegByes += delivery.Extras
+		case cricket.ExtraPenalty:
+			card.Extras.Penalty += delivery.Extras
+		}
+		bowler.Runs += conceded
+		overConceded[delivery.Bowler][delivery.Over] += conceded
+		if delivery.IsLegal() {
+			legalBalls++
+			bowlerBalls[delivery.Bowler]++
+			overBalls[delivery.Bowler][delivery.Over]++
+		}
+
+		if delivery.Wicket != nil {
+			card.Wickets++
+			if delivery.Wicket.CreditedToBowler() {
+				bowler.Wickets++
+			}
+			addBatter(delivery.Wicket.PlayerOut)
+			card.Batters[batterIndex[delivery.Wicket.PlayerOut]].Dismissal = describeDismissal(delivery)
+			card.FallOfWickets = append(card.FallOfWickets, cricket.FallOfWicket{
+				Wicket: card.Wickets,
+				Runs:   card.Runs,
+				Player: delivery.Wicket.PlayerOut,
+				Over:   formatOvers(legalBalls),
+			})
+		}
+	}
+
+	card.Overs = formatOvers(legalBalls)
+	for i := range card.Bowlers {
+		name := card.Bowlers[i].Name
+		card.Bowlers[i].Overs = formatOvers(bowlerBalls[name])
+		for over, balls := range overBalls[name] {
+			if balls == 6 && overConceded[name][over] == 0 {
+				card.Bowlers[i].Maidens++
+			}
+		}
+	}
+
+	for _, sheet := range []cricket.TeamSheet{sheets.Home, sheets.Away} {
+		if sheet.Name != innings.BattingTeam {
+			continue
+		}
+		for _, player := range sheet.Players {
+			if _, batted := batterIndex[player]; !batted {
+				card.DidNotBat = append(card.DidNotBat, player)
+			}
+		}
+	}
+
+	return card
+}
+
+// DerivePartnerships returns the partnerships of an innings in batting order
+func DerivePartnerships(innings cricket.InningsDeliveries) []cricket.Partnership {
+	partnerships := []cricket.Partnership{}
+	var current *cricket.Partnership
+
+	for _, delivery := range innings.Deliveries {
+		if current == nil {
+			partnerships = append(partnerships, cricket.Partnership{
+				Wicket:  len(partnerships) + 1,
+				Batters: [2]string{delivery.Batter, delivery.NonStriker},
+			})
+			current = &partnerships[len(partnerships)-1]
+		}
+		current.Runs += delivery.Total()
+		if delivery.IsLegal() {
+			current.Balls++
+		}
+		if delivery.Wicket != nil {
+			current = nil
+		}
+	}
+
+	if current != nil {
+		current.Unbroken = true
+	}
+	return partnerships
+}
+
+// DeriveFirsts finds the first ball, scoring shot, boundary and wicket of the match
+func DeriveFirsts(match cricket.MatchDeliveries) cricket.MatchFirsts {
+	var firsts cricket.MatchFirsts
+
+	for _, innings := range match.Innings {
+		if innings.SuperOver {
+			continue
+		}
+		for i := range innings.Deliveries {
+			delivery := &innings.Deliveries[i]
+			if firsts.FirstBall == nil {
+				firsts.FirstBall = delivery
+			}
+			if firsts.FirstScoringShot == nil && delivery.Runs > 0 {
+				firsts.FirstScoringShot = delivery
+			}
+			if firsts.FirstBoundary == nil && (delivery.Runs == 4 || delivery.Runs == 6) {
+				firsts.FirstBoundary = delivery
+			}
+			if firsts.FirstWicket == nil && delivery.Wicket != nil {
+				firsts.FirstWicket = delivery
+			}
+		}
+	}
+
+	return firsts
+}
+
+// describeDismissal writes a dismissal the way scorecards do, e.g. "c Jurel b Kartikeya"
+func describeDismissal(delivery cricket.Delivery) string {
+	wicket := delivery.Wicket
+	bowler := scorecardName(delivery.Bowler)
+
+	switch wicket.Kind {
+	case "caught":
+		if wicket.Fielder == delivery.Bowler {
+			return "c & b " + bowler
+		}
+		return fmt.Sprintf("c %s b %s", scorecardName(wicket.Fielder), bowler)
+	case "bowled":
+		return "b " + bowler
+	case "lbw":
+		return "lbw b " + bowler
+	case "stumped":
+		return fmt.Sprintf("st %s b %s", scorecardName(wicket.Fielder), bowler)
+	case "run out":
+		return fmt.Sprintf("run out (%s)", scorecardName(wicket.Fielder))
+	}
+	return wicket.Kind
+}
+
+// scorecardName shortens "R Rickelton" to "Rickelton"; names without initials are kept whole
+func scorecardName(name string) string {
+	parts := strings.Fields(name)
+	if len(parts) < 2 {
+		return name
+	}
+	for _, r := range parts[0] {
+		if !unicode.IsUpper(r) {
+			return name
+		}
+	}
+	return parts[len(parts)-1]
+}
+
+// formatOvers writes legal balls as overs, e.g. 97 balls as "16.1"
+func formatOvers(balls int) string {
+	return fmt.Sprintf("%d.%d", balls/6, balls%6)
+}
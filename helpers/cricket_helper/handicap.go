@@ -0,0 +1,93 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func init() {
+	Markets.Register(matchHandicapMarket{})
+}
+
+// handicapLines reads the wicket and run lines of a handicap option, e.g.
+// "+4.5 wkts/+12.5 runs"
+func handicapLines(name string) (wickets, runs float64, err error) {
+	found := 0
+	for _, part := range strings.Split(name, "/") {
+		value, unit, _ := strings.Cut(strings.TrimSpace(part), " ")
+		line, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			return 0, 0, fmt.Errorf("invalid handicap %q", name)
+		}
+		switch strings.TrimSpace(unit) {
+		case "wkts", "wkt", "wickets", "wicket":
+			wickets = line
+			found |= 1
+		case "runs", "run":
+			runs = line
+			found |= 2
+		default:
+			return 0, 0, fmt.Errorf("invalid handicap %q", name)
+		}
+	}
+	if found != 3 {
+		return 0, 0, fmt.Errorf("handicap %q needs a wicket and a run line", name)
+	}
+	return wickets, runs, nil
+}
+
+// matchHandicapMarket settles the "Match Handicap" market. A side's handicap
+// applies in runs when the side batting first won and in wickets when the
+// side batting second won, so it follows how the margin was recorded.
+type matchHandicapMarket struct{}
+
+func (matchHandicapMarket) MarketID() string { return "149" }
+
+func (m matchHandicapMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Match Handicap",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on a team with a handicap in runs if the side batting first wins, or in wickets if the side batting second wins",
+		ConfidenceLevel:   "Medium",
+	}
+
+	teamHandicap := func(odd cricket.Odd) string {
+		return teamOptionName(odd.Header, input.MatchInfo, odd.Header) + " " + odd.Name
+	}
+	return marketSelections(template, input.Prematch.OtherMarket("match_handicap"), teamHandicap, teamHandicap)
+}
+
+func (matchHandicapMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !settledOutcome(&selection, matchInfo) {
+		return selection
+	}
+	wickets, runs, err := handicapLines(selection.Option.Name)
+	if err != nil {
+		voidSelection(&selection, err.Error())
+		return selection
+	}
+
+	// A tie, even one decided by a super over, and a draw are a margin of 0 runs
+	outcome := matchInfo.Outcome
+	won, line, unit := 0, runs, cricket.MarginRuns
+	if outcome.Type == cricket.ResultWin {
+		won = outcome.Margin
+		if outcome.MarginUnit == cricket.MarginWickets {
+			line, unit = wickets, cricket.MarginWickets
+		}
+		if teamOption(outcome.Winner, matchInfo) != selection.Option.Header {
+			won = -won
+		}
+	}
+
+	team := teamOptionName(selection.Option.Header, matchInfo, selection.Option.Header)
+	selection.Outcome = settlement.Line(float64(won) + line)
+	selection.Evaluation = fmt.Sprintf("%s, so the %s line applies: %s %+d %+g %s = %+g",
+		outcome, unit, team, won, line, unit, float64(won)+line)
+
+	return selection
+}
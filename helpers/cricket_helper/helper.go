@@ -3,12 +3,17 @@ package cricket_helper
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
-	"time"
+
+	"github.com/yesetoda/bet365-evaluator-go/feed"
 	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
 )
 
 // LoadResultData loads cricket result data from a JSON file
@@ -16,7 +21,7 @@ func LoadCricketResultData(filename string) (cricket.CricketResultData, error) {
 	var data cricket.CricketResultData
 
 	log.Printf("Loading match result data from %s", filename)
-	fileContent, err := ioutil.ReadFile(filename)
+	fileContent, err := os.ReadFile(filename)
 	if err != nil {
 		return data, fmt.Errorf("error reading result file: %v", err)
 	}
@@ -34,7 +39,7 @@ func LoadCricketPrematchData(filename string) (cricket.CricketPrematchData, erro
 	var data cricket.CricketPrematchData
 
 	log.Printf("Loading prematch betting data from %s", filename)
-	fileContent, err := ioutil.ReadFile(filename)
+	fileContent, err := os.ReadFile(filename)
 	if err != nil {
 		return data, fmt.Errorf("error reading prematch file: %v", err)
 	}
@@ -47,733 +52,233 @@ func LoadCricketPrematchData(filename string) (cricket.CricketPrematchData, erro
 	return data, nil
 }
 
-// extractDetailedMatchInfo extracts comprehensive match information
-func ExtractDetailedMatchInfo(data cricket.CricketResultData) cricket.DetailedMatchInfo {
-	var info cricket.DetailedMatchInfo
-
-	if len(data.Results) == 0 {
-		log.Println("No match results found in data")
-		return info
-	}
-
-	result := data.Results[0]
-	info.HomeTeam = result.Home.Name
-	info.AwayTeam = result.Away.Name
-	info.Stadium = result.Extra.StadiumData.Name
-	info.City = result.Extra.StadiumData.City
-	info.Country = result.Extra.StadiumData.Country
-	info.Capacity = result.Extra.StadiumData.Capacity
-	info.LeagueName = result.League.Name
-
-	// Parse confirmed time if available
-	if result.ConfirmedAt != "" {
-		// Assuming ISO 8601 format
-		t, err := time.Parse(time.RFC3339, result.ConfirmedAt)
-		if err == nil {
-			info.MatchDate = t.Format("Monday, January 2, 2006")
-		} else {
-			info.MatchDate = "Date information unavailable"
-			log.Printf("Error parsing match date: %v", err)
-		}
-	}
-
-	// Parse the score
-	homeScore, awayScore, err := ParseScore(result.SS)
-	if err != nil {
-		log.Printf("Failed to parse score: %v", err)
-	} else {
-		info.HomeScore = homeScore
-		info.AwayScore = awayScore
-	}
-
-	// Initialize empty stats maps
-	info.BattingStats = make(map[string]cricket.BattingStats)
-	info.BowlingStats = make(map[string]cricket.BowlingStats)
-
-	return info
-}
-
-// parseScore parses the score string into home and away scores
-func ParseScore(scoreStr string) (int, int, error) {
-	// In cricket, the score format is typically "X-Y" where X is home team score and Y is away team score
-	parts := strings.Split(scoreStr, "-")
-	if len(parts) != 2 {
-		return 0, 0, fmt.Errorf("invalid score format: %s", scoreStr)
-	}
+// LoadCricketScorecardData loads cricket scorecards from a JSON file
+func LoadCricketScorecardData(filename string) (cricket.CricketScorecardData, error) {
+	var data cricket.CricketScorecardData
 
-	homeScore, err := strconv.Atoi(parts[0])
+	log.Printf("Loading scorecard data from %s", filename)
+	fileContent, err := os.ReadFile(filename)
 	if err != nil {
-		return 0, 0, fmt.Errorf("invalid home score: %s", parts[0])
+		return data, fmt.Errorf("error reading scorecard file: %v", err)
 	}
 
-	awayScore, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return 0, 0, fmt.Errorf("invalid away score: %s", parts[1])
+	if err := json.Unmarshal(fileContent, &data); err != nil {
+		return data, fmt.Errorf("error unmarshaling scorecard data: %v", err)
 	}
 
-	return homeScore, awayScore, nil
+	log.Printf("Successfully loaded scorecard data, found %d scorecards", len(data.Results))
+	return data, nil
 }
 
-// createMatchWinnerSelection creates and evaluates a match winner selection
-func CreateMatchWinnerSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "To Win the Match",
-		MarketDescription: "Bet on which team will win the match",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "High",
-	}
-
-	// Get all available options and odds for this market
-	if len(data.Results) > 0 && len(data.Results[0].Main.SP.ToWinTheMatch.Odds) > 0 {
-		// Get all available options
-		for _, odd := range data.Results[0].Main.SP.ToWinTheMatch.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-
-			// Convert option names (1=Home, 2=Away) to team names
-			optionName := ""
-			if odd.Name == "1" {
-				optionName = matchInfo.HomeTeam + " to win"
-			} else if odd.Name == "2" {
-				optionName = matchInfo.AwayTeam + " to win"
-			} else {
-				optionName = "Draw/Tie"
-			}
-
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", optionName, odds))
-		}
-
-		// Our bet selection - let's pick the away team (Mumbai Indians)
-		for _, odd := range data.Results[0].Main.SP.ToWinTheMatch.Odds {
-			if odd.Name == "2" { // Away team selection
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = matchInfo.AwayTeam + " to win"
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
+// FindScorecard returns the scorecard of a result by bet365_id or id, or nil if there is none
+func FindScorecard(data cricket.CricketScorecardData, result cricket.CricketMatchResult) *cricket.Scorecard {
+	for i, scorecard := range data.Results {
+		if (scorecard.Bet365ID != "" && scorecard.Bet365ID == result.Bet365ID) ||
+			(scorecard.ID != "" && scorecard.ID == result.ID) {
+			return &data.Results[i]
 		}
 	}
-
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
-	}
-
-	// In cricket, the higher score wins
-	if matchInfo.AwayScore > matchInfo.HomeScore {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("%s won with score %d vs %d (margin: %d runs)",
-			matchInfo.AwayTeam, matchInfo.AwayScore, matchInfo.HomeScore,
-			matchInfo.AwayScore-matchInfo.HomeScore)
-	} else if matchInfo.HomeScore > matchInfo.AwayScore {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("%s won with score %d vs %d (margin: %d runs)",
-			matchInfo.HomeTeam, matchInfo.HomeScore, matchInfo.AwayScore,
-			matchInfo.HomeScore-matchInfo.AwayScore)
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = "Match ended in a tie"
-	}
-
-	return selection
+	return nil
 }
 
-// createFirstOverRunsSelection creates and evaluates a first over total runs selection
-func CreateFirstOverRunsSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "1st Over Total Runs",
-		MarketDescription: "Bet on the total number of runs scored in the first over",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Medium",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].FirstOver.SP.FirstOverTotalRuns.Odds) > 0 {
-		for _, odd := range data.Results[0].FirstOver.SP.FirstOverTotalRuns.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s %s @ %.2f", odd.Header, odd.Name, odds))
-		}
-
-		// For this example, we'll pick "Over 6.5" runs in the first over
-		for _, odd := range data.Results[0].FirstOver.SP.FirstOverTotalRuns.Odds {
-			if odd.Header == "Over" && odd.Name == "6.5" {
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = fmt.Sprintf("Over %s runs in first over", odd.Name)
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
-		}
-	}
-
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
-	}
-
-	// For demonstration purposes, let's simulate the first over runs
-	// In a real implementation, you'd need more detailed ball-by-ball data
-	firstOverRuns := 8
-	overUnderValue := 6.5
-
-	if float64(firstOverRuns) > overUnderValue {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("First over had %d runs (> %.1f). Breakdown: 1, 4, 0, 1, 2, 0",
-			firstOverRuns, overUnderValue)
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("First over had %d runs (<= %.1f)", firstOverRuns, overUnderValue)
-	}
-
-	return selection
+// PairEvents joins every prematch entry to its result by FI/bet365_id and event_id/id
+func PairEvents(prematchData cricket.CricketPrematchData, resultData cricket.CricketResultData) ([]feed.Pair[cricket.CricketPrematchResult, cricket.CricketMatchResult], []feed.Unmatched) {
+	return feed.Join(prematchData.Results,
+		func(p cricket.CricketPrematchResult) feed.EventKey {
+			return feed.EventKey{Bet365ID: p.FI, EventID: p.EventID}
+		},
+		resultData.Results,
+		func(r cricket.CricketMatchResult) feed.EventKey {
+			return feed.EventKey{Bet365ID: r.Bet365ID, EventID: r.ID}
+		})
 }
 
-// createFirstInningsScoreSelection creates and evaluates a first innings score selection
-func CreateFirstInningsScoreSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "1st Innings Score",
-		MarketDescription: "Bet on whether the first innings score will be over or under a specific value",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Medium",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Innings1.SP.FirstInningsScore.Odds) > 0 {
-		for _, odd := range data.Results[0].Innings1.SP.FirstInningsScore.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s %s @ %.2f", odd.Header, odd.Name, odds))
-		}
-
-		// For this example, we'll pick "Under 170.5" for the first innings score
-		for _, odd := range data.Results[0].Innings1.SP.FirstInningsScore.Odds {
-			if odd.Header == "Under" && odd.Name == "170.5" {
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = fmt.Sprintf("Under %s runs in first innings", odd.Name)
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
-		}
-	}
-
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
-	}
-
-	// For demonstration, let's say the first innings score was 168
-	firstInningsScore := 168
-	thresholdValue := 170.5
-
-	if float64(firstInningsScore) < thresholdValue {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("First innings score was %d (< %.1f)",
-			firstInningsScore, thresholdValue)
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("First innings score was %d (>= %.1f)",
-			firstInningsScore, thresholdValue)
-	}
-
-	return selection
+// Event is a prematch entry joined to its result, with the scorecard and
+// ball-by-ball data of the match where the feeds have them
+type Event struct {
+	Prematch   cricket.CricketPrematchResult
+	Result     cricket.CricketMatchResult
+	Scorecard  *cricket.Scorecard
+	Deliveries *cricket.MatchDeliveries
 }
 
-// createFiftyToBeScored creates and evaluates whether a fifty will be scored
-func CreateFiftyToBeScored(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "A Fifty to be Scored",
-		MarketDescription: "Bet on whether any player will score fifty or more runs in the match",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "High",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Match.SP.AFiftyToBeScored.Odds) > 0 {
-		for _, odd := range data.Results[0].Match.SP.AFiftyToBeScored.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", odd.Name, odds))
-		}
-
-		// We'll select "Yes" for a fifty to be scored
-		for _, odd := range data.Results[0].Match.SP.AFiftyToBeScored.Odds {
-			if odd.Name == "Yes" {
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = "Yes - A fifty will be scored"
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
-		}
+// LoadEvents loads the cricket feeds in dir and joins every prematch entry
+// to its result, scorecard and deliveries
+func LoadEvents(dir string) ([]Event, []feed.Unmatched, error) {
+	resultData, err := LoadCricketResultData(filepath.Join(dir, "cricket_result.json"))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
+	prematchData, err := LoadCricketPrematchData(filepath.Join(dir, "cricket_prematch.json"))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Check if any player scored a fifty
-	fiftyScored := false
-	var fiftyScorers []string
-
-	for player, stats := range matchInfo.BattingStats {
-		if stats.Runs >= 50 {
-			fiftyScored = true
-			fiftyScorers = append(fiftyScorers, fmt.Sprintf("%s (%d)", player, stats.Runs))
-		}
+	scorecardData, err := LoadCricketScorecardData(filepath.Join(dir, "cricket_scorecard.json"))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if fiftyScored {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("A fifty was scored. Players with 50+ runs: %s",
-			strings.Join(fiftyScorers, ", "))
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = "No player scored fifty or more runs in the match"
+	deliveryData, err := LoadCricketDeliveryData(filepath.Join(dir, "cricket_deliveries.json"))
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return selection
+	pairs, unmatched := PairEvents(prematchData, resultData)
+	events := make([]Event, 0, len(pairs))
+	for _, pair := range pairs {
+		events = append(events, Event{
+			Prematch:   pair.Prematch,
+			Result:     pair.Result,
+			Scorecard:  FindScorecard(scorecardData, pair.Result),
+			Deliveries: FindDeliveries(deliveryData, pair.Result),
+		})
+	}
+	return events, unmatched, nil
 }
 
-// createSuperOverSelection creates and evaluates a super over selection
-func CreateSuperOverSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "To Go to Super Over",
-		MarketDescription: "Bet on whether the match will go to a super over",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Low",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Match.SP.ToGoToSuperOver.Odds) > 0 {
-		for _, odd := range data.Results[0].Match.SP.ToGoToSuperOver.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", odd.Name, odds))
-		}
-
-		// We'll select "Yes" for the match to go to a super over
-		for _, odd := range data.Results[0].Match.SP.ToGoToSuperOver.Odds {
-			if odd.Name == "Yes" {
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = "Yes - Match will go to super over"
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
-		}
-	}
+// extractDetailedMatchInfo extracts comprehensive match information. Player
+// statistics come from the ball-by-ball data when supplied, otherwise from
+// the scorecard.
+func ExtractDetailedMatchInfo(result cricket.CricketMatchResult, scorecard *cricket.Scorecard, deliveries *cricket.MatchDeliveries) cricket.DetailedMatchInfo {
+	var info cricket.DetailedMatchInfo
 
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
+	info.HomeTeam = result.Home.Name
+	info.AwayTeam = result.Away.Name
+	info.Stadium = result.Extra.StadiumData.Name
+	info.City = result.Extra.StadiumData.City
+	info.Country = result.Extra.StadiumData.Country
+	info.Capacity = result.Extra.StadiumData.Capacity
+	info.LeagueName = result.League.Name
 
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
+	// The match is dated by its start, or by when the result was confirmed
+	// when the feed leaves the start out
+	info.Kickoff = result.Time
+	info.InPlay = result.InplayCreatedAt
+	info.Confirmed = result.ConfirmedAt
+	info.MatchDate = "Date information unavailable"
+	switch {
+	case !info.Kickoff.IsZero():
+		info.MatchDate = info.Kickoff.InZone().Format("Monday, January 2, 2006")
+	case !info.Confirmed.IsZero():
+		info.MatchDate = info.Confirmed.InZone().Format("Monday, January 2, 2006")
 	}
 
-	// For this example, we'll say the match did not go to a super over
-	superOver := false
-
-	if superOver {
-		selection.IsWinner = true
-		selection.Evaluation = "The match went to a super over as the scores were tied"
+	// Parse the score
+	score, err := ParseMatchScore(result.SS)
+	if err != nil {
+		log.Printf("Failed to parse score: %v", err)
 	} else {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("The match did not go to a super over. %s won by %d runs",
-			matchInfo.AwayTeam, matchInfo.AwayScore-matchInfo.HomeScore)
+		info.Score = score
 	}
 
-	return selection
-}
-
-// createMostSixesSelection creates and evaluates a most match sixes selection
-func CreateMostSixesSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "Most Match Sixes",
-		MarketDescription: "Bet on which team will hit the most sixes in the match",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Medium",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Match.SP.MostMatchSixes.Odds) > 0 {
-		for _, odd := range data.Results[0].Match.SP.MostMatchSixes.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-
-			optionName := ""
-			if odd.Name == "1" {
-				optionName = matchInfo.HomeTeam
-			} else if odd.Name == "2" {
-				optionName = matchInfo.AwayTeam
-			} else {
-				optionName = "Tie"
-			}
+	info.BattingStats = make(map[string]cricket.BattingStats)
+	info.BowlingStats = make(map[string]cricket.BowlingStats)
 
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", optionName, odds))
+	if deliveries != nil {
+		derived := DeriveScorecard(*deliveries, scorecard)
+		if scorecard != nil {
+			checkDerivedScorecard(*scorecard, derived)
 		}
+		scorecard = &derived
 
-		// We'll select the away team (Mumbai Indians) to hit the most sixes
-		for _, odd := range data.Results[0].Match.SP.MostMatchSixes.Odds {
-			if odd.Name == "2" { // Away team
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = fmt.Sprintf("%s to hit most sixes", matchInfo.AwayTeam)
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
+		info.Deliveries = deliveries
+		for _, innings := range deliveries.Innings {
+			info.Partnerships = append(info.Partnerships, DerivePartnerships(innings))
 		}
+		info.Firsts = DeriveFirsts(*deliveries)
 	}
 
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
-	}
-
-	// Count sixes for each team
-	homeSixes := 0
-	awaySixes := 0
-
-	for player, stats := range matchInfo.BattingStats {
-		if strings.Contains(player, "Yashasvi") || strings.Contains(player, "Buttler") || strings.Contains(player, "Samson") {
-			// These are home team players
-			homeSixes += stats.Sixes
-		} else {
-			// These are away team players
-			awaySixes += stats.Sixes
+	// The scorecard holds the wickets and overs of every innings, so its score is preferred
+	if scorecard != nil {
+		fromScorecard := scorecardScore(*scorecard, info.HomeTeam)
+		if err == nil {
+			checkScore(result.ID, info.Score, fromScorecard)
 		}
+		info.Score = fromScorecard
 	}
+	info.HomeScore = info.Score.Home.Runs()
+	info.AwayScore = info.Score.Away.Runs()
 
-	if awaySixes > homeSixes {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("%s hit more sixes (%d) than %s (%d)",
-			matchInfo.AwayTeam, awaySixes, matchInfo.HomeTeam, homeSixes)
-	} else if homeSixes > awaySixes {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("%s hit more sixes (%d) than %s (%d)",
-			matchInfo.HomeTeam, homeSixes, matchInfo.AwayTeam, awaySixes)
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("Both teams hit the same number of sixes (%d)", homeSixes)
-	}
+	info.Outcome = DeriveOutcome(result, scorecard, info.Score)
 
-	return selection
-}
+	if scorecard == nil {
+		log.Printf("No scorecard for event %s, player markets cannot be settled", result.ID)
+		return info
+	}
 
-// createMostFoursSelection creates and evaluates a most match fours selection
-func CreateMostFoursSelection(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "Most Match Fours",
-		MarketDescription: "Bet on which team will hit the most fours in the match",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Medium",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Match.SP.MostMatchFours.Odds) > 0 {
-		for _, odd := range data.Results[0].Match.SP.MostMatchFours.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-
-			optionName := ""
-			if odd.Name == "1" {
-				optionName = matchInfo.HomeTeam
-			} else if odd.Name == "2" {
-				optionName = matchInfo.AwayTeam
-			} else {
-				optionName = "Tie"
+	info.Scorecard = scorecard
+	for _, innings := range scorecard.Innings {
+		for _, batter := range innings.Batters {
+			stats := info.BattingStats[batter.Name]
+			stats.Team = innings.BattingTeam
+			stats.Runs += batter.Runs
+			stats.Balls += batter.Balls
+			stats.Boundaries += batter.Fours
+			stats.Sixes += batter.Sixes
+			if stats.Balls > 0 {
+				stats.StrikeRate = float64(stats.Runs) / float64(stats.Balls) * 100
 			}
-
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", optionName, odds))
+			info.BattingStats[batter.Name] = stats
 		}
 
-		// We'll select the away team (Mumbai Indians) to hit the most fours
-		for _, odd := range data.Results[0].Match.SP.MostMatchFours.Odds {
-			if odd.Name == "2" { // Away team
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = fmt.Sprintf("%s to hit most fours", matchInfo.AwayTeam)
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
+		for _, bowler := range innings.Bowlers {
+			stats := info.BowlingStats[bowler.Name]
+			balls := oversToBalls(stats.Overs) + oversToBalls(parseOvers(bowler.Overs))
+			stats.Team = innings.BowlingTeam
+			stats.Overs = float64(balls/6) + float64(balls%6)/10
+			stats.RunsConceded += bowler.Runs
+			stats.Wickets += bowler.Wickets
+			if balls > 0 {
+				stats.Economy = float64(stats.RunsConceded) / float64(balls) * 6
 			}
+			info.BowlingStats[bowler.Name] = stats
 		}
 	}
 
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
-	}
-
-	// Count fours for each team
-	homeFours := 0
-	awayFours := 0
-
-	for player, stats := range matchInfo.BattingStats {
-		if strings.Contains(player, "Yashasvi") || strings.Contains(player, "Buttler") || strings.Contains(player, "Samson") {
-			// These are home team players
-			homeFours += stats.Boundaries
-		} else {
-			// These are away team players
-			awayFours += stats.Boundaries
-		}
-	}
-
-	if awayFours > homeFours {
-		selection.IsWinner = true
-		selection.Evaluation = fmt.Sprintf("%s hit more fours (%d) than %s (%d)",
-			matchInfo.AwayTeam, awayFours, matchInfo.HomeTeam, homeFours)
-	} else if homeFours > awayFours {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("%s hit more fours (%d) than %s (%d)",
-			matchInfo.HomeTeam, homeFours, matchInfo.AwayTeam, awayFours)
-	} else {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("Both teams hit the same number of fours (%d)", homeFours)
-	}
-
-	return selection
+	return info
 }
 
-// createHundredToBeScored creates and evaluates a hundred to be scored selection
-func CreateHundredToBeScored(data cricket.CricketPrematchData, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
-	selection := cricket.BetSelection{
-		Market:            "A Hundred to be Scored",
-		MarketDescription: "Bet on whether any player will score a century in the match",
-		AvailableOptions:  []string{},
-		ConfidenceLevel:   "Low",
-	}
-
-	// Get all available options for this market
-	if len(data.Results) > 0 && len(data.Results[0].Match.SP.AHundredToBeScored.Odds) > 0 {
-		for _, odd := range data.Results[0].Match.SP.AHundredToBeScored.Odds {
-			odds, _ := strconv.ParseFloat(odd.Odds, 64)
-			selection.AvailableOptions = append(selection.AvailableOptions,
-				fmt.Sprintf("%s @ %.2f", odd.Name, odds))
+// checkDerivedScorecard logs innings where the supplied scorecard disagrees with the deliveries
+func checkDerivedScorecard(supplied, derived cricket.Scorecard) {
+	for i, innings := range derived.Innings {
+		if i >= len(supplied.Innings) {
+			log.Printf("Innings %d is missing from the scorecard of event %s", innings.Number, derived.ID)
+			continue
 		}
-
-		// We'll select "No" for a hundred to be scored
-		for _, odd := range data.Results[0].Match.SP.AHundredToBeScored.Odds {
-			if odd.Name == "No" {
-				odds, _ := strconv.ParseFloat(odd.Odds, 64)
-				selection.Selection = "No - A hundred will not be scored"
-				selection.Odds = odds
-				selection.OddsDecimal = odds
-
-				// Convert to American odds
-				if odds >= 2.0 {
-					americanOdds := (odds - 1) * 100
-					selection.OddsAmerican = fmt.Sprintf("+%.0f", americanOdds)
-				} else {
-					americanOdds := -100 / (odds - 1)
-					selection.OddsAmerican = fmt.Sprintf("%.0f", americanOdds)
-				}
-
-				// Convert to fractional odds (simplified)
-				num := int((odds - 1) * 100)
-				den := 100
-				gcd := GreatestCommonDivisor(num, den)
-				selection.OddsFractional = fmt.Sprintf("%d/%d", num/gcd, den/gcd)
-
-				break
-			}
+		if supplied.Innings[i].Runs != innings.Runs || supplied.Innings[i].Wickets != innings.Wickets {
+			log.Printf("Innings %d of event %s: scorecard has %d/%d, deliveries add up to %d/%d",
+				innings.Number, derived.ID, supplied.Innings[i].Runs, supplied.Innings[i].Wickets,
+				innings.Runs, innings.Wickets)
 		}
 	}
+}
 
-	// Calculate potential profit with $100 stake
-	selection.PotentialProfit = 100.0 * (selection.Odds - 1)
-
-	// Set risk assessment based on odds
-	if selection.Odds < 1.5 {
-		selection.RiskAssessment = "Low Risk"
-	} else if selection.Odds < 3.0 {
-		selection.RiskAssessment = "Medium Risk"
-	} else {
-		selection.RiskAssessment = "High Risk"
+// parseOvers parses an overs string such as "16.1"
+func parseOvers(overs string) float64 {
+	value, err := strconv.ParseFloat(overs, 64)
+	if err != nil {
+		log.Printf("Invalid overs value %q: %v", overs, err)
+		return 0
 	}
+	return value
+}
 
-	// Check if any player scored a hundred
-	hundredScored := false
-	var centuryScorers []string
-
-	for player, stats := range matchInfo.BattingStats {
-		if stats.Runs >= 100 {
-			hundredScored = true
-			centuryScorers = append(centuryScorers, fmt.Sprintf("%s (%d)", player, stats.Runs))
-		}
-	}
+// oversToBalls converts overs written as 16.1 (16 overs and 1 ball) to legal balls
+func oversToBalls(overs float64) int {
+	whole := int(overs)
+	return whole*6 + int(math.Round((overs-float64(whole))*10))
+}
 
-	if hundredScored {
-		selection.IsWinner = false
-		selection.Evaluation = fmt.Sprintf("A hundred was scored. Players with 100+ runs: %s",
-			strings.Join(centuryScorers, ", "))
-	} else {
-		selection.IsWinner = true
-		selection.Evaluation = "No player scored a hundred in the match. Highest score was by Rohit Sharma (67 runs)"
+// parseScore parses the score string into the home and away runs, summed
+// across innings; ParseMatchScore keeps the innings apart
+func ParseScore(scoreStr string) (int, int, error) {
+	score, err := ParseMatchScore(scoreStr)
+	if err != nil {
+		return 0, 0, err
 	}
-
-	return selection
+	return score.Home.Runs(), score.Away.Runs(), nil
 }
 
 func PrintMatchHeader(info cricket.DetailedMatchInfo) {
@@ -782,9 +287,12 @@ func PrintMatchHeader(info cricket.DetailedMatchInfo) {
 	fmt.Println("===========================================================")
 	fmt.Printf("Match: %s vs %s\n", info.HomeTeam, info.AwayTeam)
 	fmt.Printf("Date: %s\n", info.MatchDate)
+	fmt.Printf("Start: %s\n", info.Kickoff.Display())
+	fmt.Printf("In-play: %s\n", info.InPlay.Display())
+	fmt.Printf("Result confirmed: %s\n", info.Confirmed.Display())
 	fmt.Printf("Venue: %s, %s, %s (Capacity: %s)\n", info.Stadium, info.City, info.Country, info.Capacity)
 	fmt.Printf("Competition: %s\n", info.LeagueName)
-	fmt.Printf("Final Score: %s %d - %d %s\n", info.HomeTeam, info.HomeScore, info.AwayScore, info.AwayTeam)
+	fmt.Printf("Final Score: %s %s - %s %s\n", info.HomeTeam, info.Score.Home, info.Score.Away, info.AwayTeam)
 	fmt.Println("-----------------------------------------------------------")
 }
 
@@ -794,8 +302,20 @@ func PrintDetailedMatchStats(info cricket.DetailedMatchInfo) {
 	fmt.Printf("%-20s %-8s %-8s %-8s %-8s %-8s\n", "PLAYER", "RUNS", "BALLS", "SR", "4s", "6s")
 	fmt.Println("-------------------------------------------------------")
 
-	// Print batting stats
-	for player, stats := range info.BattingStats {
+	// Print batting stats, highest scores first
+	batters := make([]string, 0, len(info.BattingStats))
+	for player := range info.BattingStats {
+		batters = append(batters, player)
+	}
+	sort.Slice(batters, func(i, j int) bool {
+		a, b := info.BattingStats[batters[i]], info.BattingStats[batters[j]]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return batters[i] < batters[j]
+	})
+	for _, player := range batters {
+		stats := info.BattingStats[player]
 		fmt.Printf("%-20s %-8d %-8d %-8.2f %-8d %-8d\n",
 			player, stats.Runs, stats.Balls, stats.StrikeRate, stats.Boundaries, stats.Sixes)
 	}
@@ -804,15 +324,87 @@ func PrintDetailedMatchStats(info cricket.DetailedMatchInfo) {
 	fmt.Printf("%-20s %-8s %-8s %-8s %-8s\n", "PLAYER", "OVERS", "RUNS", "WICKETS", "ECON")
 	fmt.Println("-------------------------------------------------------")
 
-	// Print bowling stats
-	for player, stats := range info.BowlingStats {
+	// Print bowling stats, most wickets first
+	bowlers := make([]string, 0, len(info.BowlingStats))
+	for player := range info.BowlingStats {
+		bowlers = append(bowlers, player)
+	}
+	sort.Slice(bowlers, func(i, j int) bool {
+		a, b := info.BowlingStats[bowlers[i]], info.BowlingStats[bowlers[j]]
+		if a.Wickets != b.Wickets {
+			return a.Wickets > b.Wickets
+		}
+		if a.RunsConceded != b.RunsConceded {
+			return a.RunsConceded < b.RunsConceded
+		}
+		return bowlers[i] < bowlers[j]
+	})
+	for _, player := range bowlers {
+		stats := info.BowlingStats[player]
 		fmt.Printf("%-20s %-8.1f %-8d %-8d %-8.2f\n",
 			player, stats.Overs, stats.RunsConceded, stats.Wickets, stats.Economy)
 	}
 
+	if info.Scorecard != nil {
+		fmt.Println("\n===== FALL OF WICKETS =====")
+		for _, innings := range info.Scorecard.Innings {
+			fows := []string{}
+			for _, fow := range innings.FallOfWickets {
+				fows = append(fows, fmt.Sprintf("%d-%d (%s, %s ov)", fow.Wicket, fow.Runs, fow.Player, fow.Over))
+			}
+			fmt.Printf("%s %d/%d (%s ov): %s\n", innings.BattingTeam, innings.Runs, innings.Wickets,
+				innings.Overs, strings.Join(fows, ", "))
+		}
+	}
+
+	if len(info.Partnerships) > 0 {
+		fmt.Println("\n===== PARTNERSHIPS =====")
+		for i, partnerships := range info.Partnerships {
+			fmt.Printf("%s\n", info.Deliveries.Innings[i].BattingTeam)
+			for _, partnership := range partnerships {
+				unbroken := ""
+				if partnership.Unbroken {
+					unbroken = " *"
+				}
+				fmt.Printf("  %-4s %-40s %d (%d)%s\n", ordinalWicket(partnership.Wicket),
+					partnership.Batters[0]+" & "+partnership.Batters[1], partnership.Runs, partnership.Balls, unbroken)
+			}
+		}
+	}
+
+	if info.Firsts.FirstBall != nil {
+		fmt.Println("\n===== MATCH FIRSTS =====")
+		first := info.Firsts.FirstBall
+		fmt.Printf("First ball: %s to %s, %d run(s)\n", first.Bowler, first.Batter, first.Total())
+		if shot := info.Firsts.FirstScoringShot; shot != nil {
+			fmt.Printf("First scoring shot: %d by %s (%d.%d)\n", shot.Runs, shot.Batter, shot.Over-1, shot.Ball)
+		}
+		if boundary := info.Firsts.FirstBoundary; boundary != nil {
+			fmt.Printf("First boundary: %d by %s (%d.%d)\n", boundary.Runs, boundary.Batter, boundary.Over-1, boundary.Ball)
+		}
+		if wicket := info.Firsts.FirstWicket; wicket != nil {
+			fmt.Printf("First wicket: %s, %s (%d.%d)\n", wicket.Wicket.PlayerOut, describeDismissal(*wicket), wicket.Over-1, wicket.Ball)
+		}
+	}
+
 	fmt.Println("-----------------------------------------------------------")
 }
 
+// ordinalWicket labels a partnership by its wicket, e.g. "1st"
+func ordinalWicket(wicket int) string {
+	switch {
+	case wicket%100 >= 11 && wicket%100 <= 13:
+		return fmt.Sprintf("%dth", wicket)
+	case wicket%10 == 1:
+		return fmt.Sprintf("%dst", wicket)
+	case wicket%10 == 2:
+		return fmt.Sprintf("%dnd", wicket)
+	case wicket%10 == 3:
+		return fmt.Sprintf("%drd", wicket)
+	}
+	return fmt.Sprintf("%dth", wicket)
+}
+
 // printBettingEvaluationHeader prints the header for the betting evaluation
 func PrintBettingEvaluationHeader() {
 	fmt.Println("\n===========================================================")
@@ -826,29 +418,68 @@ func PrintBetSelectionDetails(index int, selection cricket.BetSelection) {
 	fmt.Printf("   Description: %s\n", selection.MarketDescription)
 	fmt.Printf("   Selection: %s @ %.2f (Decimal: %.2f, American: %s, Fractional: %s)\n",
 		selection.Selection, selection.Odds, selection.OddsDecimal, selection.OddsAmerican, selection.OddsFractional)
-	fmt.Printf("   Potential Profit ($100 stake): $%.2f\n", selection.PotentialProfit)
+	fmt.Printf("   Potential Profit (%s stake): %s\n", selection.StakeAmount, selection.PotentialProfit)
 	fmt.Printf("   Risk Assessment: %s\n", selection.RiskAssessment)
+	if selection.FairOdds > 0 {
+		fmt.Printf("   Implied Probability: %.2f%% (fair %.2f%%)\n", 100/selection.Odds, 100/selection.FairOdds)
+	}
 	fmt.Printf("   Confidence Level: %s\n", selection.ConfidenceLevel)
 
 	fmt.Printf("   Available Options: %s\n", strings.Join(selection.AvailableOptions, " | "))
 
-	if selection.IsWinner {
-		fmt.Printf("   Result: WIN - %s\n", selection.Evaluation)
-	} else {
-		fmt.Printf("   Result: LOSS - %s\n", selection.Evaluation)
-	}
+	fmt.Printf("   Result: %s - %s\n", selection.Outcome, selection.Evaluation)
+	fmt.Printf("   Return: %s\n", selection.Outcome.Return(selection.StakeAmount, selection.Price))
 }
 
 // printBettingEvaluationSummary prints the summary of the betting evaluation
-func PrintBettingEvaluationSummary(wins, total int, stake, returns, profitLoss, roi float64) {
+func PrintBettingEvaluationSummary(summary settlement.Summary) {
 	fmt.Println("\n===========================================================")
 	fmt.Println("                      OVERALL SUMMARY                      ")
 	fmt.Println("===========================================================")
-	fmt.Printf("Winning Bets: %d/%d (%.1f%%)\n", wins, total, float64(wins)/float64(total)*100)
-	fmt.Printf("Total Stake: $%.2f\n", stake*float64(total))
-	fmt.Printf("Total Returns: $%.2f\n", returns)
-	fmt.Printf("Profit/Loss: $%.2f\n", profitLoss)
-	fmt.Printf("ROI: %.2f%%\n", roi)
+	winRate := 0.0
+	if summary.Bets > 0 {
+		winRate = float64(summary.Winners()) / float64(summary.Bets) * 100
+	}
+	fmt.Printf("Winning Bets: %d/%d (%.1f%%)\n", summary.Winners(), summary.Bets, winRate)
+	fmt.Printf("Outcomes: %s\n", summary.Breakdown())
+	fmt.Printf("Total Stake: %s\n", summary.Stake)
+	fmt.Printf("Total Returns: %s\n", summary.Returns)
+	fmt.Printf("Profit/Loss: %s\n", summary.ProfitLoss())
+	fmt.Printf("ROI: %.2f%%\n", summary.ROI())
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// PrintUnmatchedEvents lists the events that appear on only one side of the feed
+func PrintUnmatchedEvents(unmatched []feed.Unmatched) {
+	if len(unmatched) == 0 {
+		return
+	}
+
+	fmt.Println("\n===========================================================")
+	fmt.Println("                     UNMATCHED EVENTS                      ")
+	fmt.Println("===========================================================")
+	for _, event := range unmatched {
+		fmt.Printf("Event %s (Bet365 ID %s): only found in %s data\n", event.Key.EventID, event.Key.Bet365ID, event.Source)
+	}
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// PrintUnsupportedMarkets lists the prematch markets that cannot be settled yet
+func PrintUnsupportedMarkets(unsupported []UnsupportedMarket) {
+	if len(unsupported) == 0 {
+		return
+	}
+
+	fmt.Println("\n===========================================================")
+	fmt.Println("                   UNSUPPORTED MARKETS                     ")
+	fmt.Println("===========================================================")
+	for _, market := range unsupported {
+		id := market.ID
+		if id == "" {
+			id = "-"
+		}
+		fmt.Printf("%-50s ID %-7s %3d odds  unsupported (%s)\n", market.Key, id, market.Odds, strings.Join(market.Sections, ", "))
+	}
 	fmt.Println("-----------------------------------------------------------")
 }
 
@@ -862,33 +493,9 @@ func PrintBettingHistory(history []cricket.BettingHistory) {
 	fmt.Println("-----------------------------------------------------------")
 
 	for _, record := range history {
-		fmt.Printf("%-25s %-10.1f%% %-10.2f %-10d $%-10.2f\n",
+		fmt.Printf("%-25s %-10.1f%% %-10.2f %-10d %-11s\n",
 			record.Market, record.WinPercentage, record.AvgOdds, record.TotalBets, record.ProfitLoss)
 	}
 
 	fmt.Println("-----------------------------------------------------------")
 }
-
-// GreatestCommonDivisor calculates GCD using Euclidean algorithm
-func GreatestCommonDivisor(a, b int) int {
-	for b != 0 {
-		a, b = b, a%b
-	}
-	return a
-}
-
-// Helper function to convert decimal odds to American format
-func DecimalToAmerican(odds float64) string {
-	if odds >= 2.0 {
-		return fmt.Sprintf("+%.0f", (odds-1)*100)
-	}
-	return fmt.Sprintf("%.0f", -100/(odds-1))
-}
-
-// Helper function to convert decimal odds to fractional format
-func DecimalToFractional(odds float64) string {
-	numerator := int((odds - 1) * 100)
-	denominator := 100
-	gcd := GreatestCommonDivisor(numerator, denominator)
-	return fmt.Sprintf("%d/%d", numerator/gcd, denominator/gcd)
-}
@@ -0,0 +1,237 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/pricing"
+)
+
+// milestoneHeader matches the headers of milestone markets, e.g. "10+ Runs",
+// whose options are not mutually exclusive
+var milestoneHeader = regexp.MustCompile(`^\d+\+`)
+
+// isNumber reports whether text is a line or a range of lines, e.g. "6.5" or "18 - 28"
+func isNumber(text string) bool {
+	for _, part := range strings.Split(text, "-") {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(part), 64); err != nil {
+			return false
+		}
+	}
+	return text != ""
+}
+
+// bookKey names the book an option belongs to within its market: options of
+// the same book are mutually exclusive, e.g. Over and Under of one line, or
+// Yes and No for one player. between is set for three-way Over/Between/Under
+// markets, whose lines differ between the options of one book.
+func bookKey(odd cricket.Odd, between bool) string {
+	switch {
+	case odd.Header == "Over" || odd.Header == "Under" || odd.Header == "Between":
+		subject, line := "", odd.Handicap
+		if isNumber(odd.Name) {
+			line = odd.Name
+		} else {
+			subject = odd.Name
+		}
+		if between {
+			return subject
+		}
+		return strings.TrimSpace(subject + " " + line)
+	case odd.Name == "Over" || odd.Name == "Under":
+		return odd.Handicap
+	case strings.HasPrefix(odd.Name, "Over ") || strings.HasPrefix(odd.Name, "Under "):
+		_, line, _ := strings.Cut(odd.Name, " ")
+		return line
+	case milestoneHeader.MatchString(odd.Header):
+		return odd.Name + " " + odd.Header
+	case odd.Header != "":
+		// Both sides of a handicap share the line without its sign
+		return strings.NewReplacer("+", "", "-", "").Replace(odd.Name)
+	}
+	return odd.Name2
+}
+
+// bookLabel names an option within its book, e.g. "Over 6.5" or a team name
+func bookLabel(odd cricket.Odd, matchInfo cricket.DetailedMatchInfo) string {
+	switch {
+	case odd.Header == "Over" || odd.Header == "Under" || odd.Header == "Between":
+		if isNumber(odd.Name) {
+			return odd.Header + " " + odd.Name
+		}
+		return strings.TrimSpace(odd.Header + " " + odd.Handicap)
+	case odd.Name == "Over" || odd.Name == "Under":
+		return strings.TrimSpace(odd.Name + " " + odd.Handicap)
+	case odd.Header != "" && strings.ContainsAny(odd.Name, "+-"):
+		return teamOptionName(odd.Header, matchInfo, odd.Header) + " " + odd.Name
+	case odd.Header == "1" || odd.Header == "2":
+		// Head-to-head options name both players, e.g. "Y Jaiswal v N Rana"
+		if players := strings.Split(odd.Name, " v "); len(players) == 2 {
+			return players[map[string]int{"1": 0, "2": 1}[odd.Header]]
+		}
+		return odd.Header
+	case odd.Header != "":
+		return odd.Header
+	}
+	return teamOptionName(odd.Name, matchInfo, odd.Name)
+}
+
+// optionBook is a set of mutually exclusive options of a market
+type optionBook struct {
+	Key        string       // Subject shared by the options, e.g. a line or a player; empty for the whole market
+	Occurrence int          // Books repeating a key without naming their subject are told apart by order
+	Subject    *cricket.Odd // "PC" row naming the subject of the book, if any
+	Odds       []cricket.Odd
+}
+
+// name describes the book for reports, e.g. "Mumbai Indians 4.5"
+func (b optionBook) name(matchInfo cricket.DetailedMatchInfo) string {
+	name := teamOptionName(b.Key, matchInfo, b.Key)
+	if b.Subject != nil {
+		subject := teamOptionName(b.Subject.Name, matchInfo, b.Subject.Name)
+		if b.Subject.Header != "" {
+			subject = teamOptionName(b.Subject.Header, matchInfo, b.Subject.Header) + " " + subject
+		}
+		return strings.TrimSpace(subject + " " + name)
+	}
+	if b.Occurrence > 0 {
+		name = strings.TrimSpace(fmt.Sprintf("%s (%d)", name, b.Occurrence+1))
+	}
+	return name
+}
+
+// marketBooks splits the priced options of a market into books, in feed order.
+// Grid markets list their subjects in "PC<id>" rows: team player markets price
+// each player in an "Odds" row with the bare id, so those options are named
+// after the player and booked by team. Other grid rows repeat the same option
+// once per subject, so the nth repeat of an option joins the nth book.
+func marketBooks(market cricket.Market) []optionBook {
+	between := false
+	subjects := make(map[string]cricket.Odd)
+	for _, odd := range market.Odds {
+		between = between || odd.Header == "Between"
+		if id, ok := strings.CutPrefix(odd.ID, "PC"); ok {
+			subjects[id] = odd
+		}
+	}
+
+	books := []optionBook{}
+	index := make(map[string]int)
+	repeats := make(map[string]int)
+	for _, odd := range market.Odds {
+		if odds, err := strconv.ParseFloat(odd.Odds, 64); err != nil || odds <= 0 {
+			continue
+		}
+		subject, named := subjects[odd.ID]
+		if named && (odd.Header == "Odds" || odd.Header == "") {
+			odd.Name, odd.Name2, odd.Header = subject.Name, subject.Header, ""
+			named = false
+		}
+
+		key := bookKey(odd, between)
+		option := key + "|" + odd.Header + "|" + odd.Name
+		occurrence := repeats[option]
+		repeats[option]++
+
+		id := fmt.Sprintf("%s#%d", key, occurrence)
+		i, ok := index[id]
+		if !ok {
+			i = len(books)
+			index[id] = i
+			books = append(books, optionBook{Key: key, Occurrence: occurrence})
+		}
+		if named {
+			books[i].Subject = &subject
+		}
+		books[i].Odds = append(books[i].Odds, odd)
+	}
+	return books
+}
+
+// bookOdds returns the quoted odds of the options of a book
+func bookOdds(odds []cricket.Odd) []float64 {
+	quoted := []float64{}
+	for _, odd := range odds {
+		value, _ := strconv.ParseFloat(odd.Odds, 64)
+		quoted = append(quoted, value)
+	}
+	return quoted
+}
+
+// marketFairOdds returns the fair odds of every option of a market under the
+// default de-vigging method, keyed by option ID. Options whose book cannot be
+// de-vigged, such as milestones, are left out.
+func marketFairOdds(market cricket.Market) map[string]float64 {
+	fair := make(map[string]float64)
+	for _, book := range marketBooks(market) {
+		prices, err := pricing.DevigOdds(bookOdds(book.Odds), pricing.DefaultMethod)
+		if err != nil {
+			continue
+		}
+		for i, odd := range book.Odds {
+			fair[odd.ID] = prices[i]
+		}
+	}
+	return fair
+}
+
+// MarketMargins reports the overround of every book of the prematch catalog,
+// once per market key and ID, with the fair odds of its options under each
+// de-vigging method. Books with a single option carry no measurable margin
+// and are left out.
+func MarketMargins(prematch cricket.CricketPrematchResult, matchInfo cricket.DetailedMatchInfo) []pricing.Margin {
+	margins := []pricing.Margin{}
+	seen := make(map[string]bool)
+	for _, entry := range prematch.Catalog {
+		id := entry.Key + "/" + entry.Market.ID
+		books := marketBooks(entry.Market)
+		if seen[id] || len(books) == 0 {
+			continue
+		}
+		seen[id] = true
+
+		for _, options := range books {
+			if len(options.Odds) < 2 {
+				continue
+			}
+			book := pricing.Book{Market: entry.Market.Name}
+			if name := options.name(matchInfo); name != "" {
+				book.Market += " - " + name
+			}
+			for i, odd := range options.Odds {
+				book.Add(bookLabel(odd, matchInfo), bookOdds(options.Odds)[i])
+			}
+			margins = append(margins, pricing.NewMargin(book))
+		}
+	}
+	return margins
+}
+
+// LadderQuotes lists every quoted price of the prematch catalog, once per
+// market key and ID, for checking against the fractional ladder
+func LadderQuotes(prematch cricket.CricketPrematchResult, matchInfo cricket.DetailedMatchInfo) []odds.Quote {
+	quotes := []odds.Quote{}
+	seen := make(map[string]bool)
+	for _, entry := range prematch.Catalog {
+		id := entry.Key + "/" + entry.Market.ID
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		for _, options := range marketBooks(entry.Market) {
+			market := entry.Market.Name
+			if name := options.name(matchInfo); name != "" {
+				market += " - " + name
+			}
+			for i, odd := range options.Odds {
+				quotes = append(quotes, odds.Quote{Market: market, Selection: bookLabel(odd, matchInfo), Decimal: bookOdds(options.Odds)[i]})
+			}
+		}
+	}
+	return quotes
+}
@@ -0,0 +1,956 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/betslip"
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/registry"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// Markets holds the cricket market processors keyed by Bet365 market ID
+var Markets = registry.New[cricket.MarketInput, cricket.DetailedMatchInfo, cricket.BetSelection, cricket.BetSelection]()
+
+func init() {
+	Markets.Register(matchWinnerMarket{})
+	Markets.Register(firstOverRunsMarket{})
+	Markets.Register(firstInningsScoreMarket{})
+	Markets.Register(fiftyToBeScoredMarket{})
+	Markets.Register(superOverMarket{})
+	Markets.Register(mostSixesMarket{})
+	Markets.Register(mostFoursMarket{})
+	Markets.Register(hundredToBeScoredMarket{})
+	Markets.Register(raceToTenRunsMarket{})
+	Markets.Register(firstBallDotMarket{})
+	Markets.Register(firstScoringShotMarket{})
+	Markets.Register(mostRunsInOverMarket{})
+	Markets.Register(sixBoundariesInOverMarket{})
+}
+
+// CreateBetSelections extracts the selections offered by every registered market
+func CreateBetSelections(input cricket.MarketInput) []cricket.BetSelection {
+	return Markets.Process(input)
+}
+
+// UnsupportedMarket is a prematch market that no registered processor settles
+type UnsupportedMarket struct {
+	Key      string
+	ID       string
+	Name     string
+	Sections []string // Every section the market appears in
+	Odds     int      // Most odds listed by any of its copies
+}
+
+// UnsupportedMarkets lists the markets of the prematch catalog without a
+// processor, once per key and ID, in catalog order
+func UnsupportedMarkets(prematch cricket.CricketPrematchResult) []UnsupportedMarket {
+	unsupported := []UnsupportedMarket{}
+	index := make(map[string]int)
+	for _, entry := range prematch.Catalog {
+		if _, ok := Markets.Lookup(entry.Market.ID); ok {
+			continue
+		}
+		id := entry.Key + "/" + entry.Market.ID
+		i, seen := index[id]
+		if !seen {
+			i = len(unsupported)
+			index[id] = i
+			unsupported = append(unsupported, UnsupportedMarket{Key: entry.Key, ID: entry.Market.ID, Name: entry.Market.Name})
+		}
+		unsupported[i].Sections = append(unsupported[i].Sections, entry.Section)
+		unsupported[i].Odds = max(unsupported[i].Odds, len(entry.Market.Odds))
+	}
+	return unsupported
+}
+
+// ApplyBetSlip stakes the slip lines that refer to the offered selections of an
+// event. It returns the accepted selections, a report per matched line and the
+// lines that belong to no selection of this event.
+func ApplyBetSlip(lines []betslip.Line, offered []cricket.BetSelection) ([]cricket.BetSelection, []betslip.Report, []betslip.Line) {
+	found, remaining := betslip.Match(lines, offered, func(s cricket.BetSelection) string { return s.SelectionID })
+
+	selections := []cricket.BetSelection{}
+	reports := []betslip.Report{}
+	for _, match := range found {
+		selection := match.Selection
+		priceSelection(&selection, match.Report.Check(selection.Odds, selection.Closed))
+		selection.StakeAmount = match.Report.Line.Stake
+		selection.PotentialProfit = settlement.WinOrLose(true).Return(selection.StakeAmount, selection.Price).Sub(selection.StakeAmount)
+		if match.Report.Status == betslip.StatusAccepted {
+			selections = append(selections, selection)
+		}
+		reports = append(reports, match.Report)
+	}
+
+	return selections, reports, remaining
+}
+
+// SettleLegs settles the multiple legs offered by an event. It returns the
+// settled legs and the lines that belong to no selection of this event.
+func SettleLegs(lines []betslip.Line, offered []cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) ([]betslip.Leg, []betslip.Line) {
+	found, remaining := betslip.Match(lines, offered, func(s cricket.BetSelection) string { return s.SelectionID })
+
+	legs := []betslip.Leg{}
+	for _, match := range found {
+		selection := match.Selection
+		priceSelection(&selection, match.Report.Check(selection.Odds, selection.Closed))
+		if match.Report.Status == betslip.StatusAccepted {
+			if settled, err := Markets.Evaluate(selection.MarketID, selection, matchInfo); err != nil {
+				match.Report.Status = betslip.StatusRejected
+				match.Report.Messages = append(match.Report.Messages, err.Error())
+			} else {
+				selection = settled
+			}
+		}
+		legs = append(legs, betslip.Leg{
+			Number:      match.Report.Line.Number,
+			SelectionID: selection.SelectionID,
+			Market:      selection.Market,
+			Description: fmt.Sprintf("%s: %s", selection.Market, selection.Selection),
+			Odds:        selection.Odds,
+			Price:       selection.Price,
+			Outcome:     selection.Outcome,
+			Evaluation:  selection.Evaluation,
+			Report:      match.Report,
+		})
+	}
+
+	return legs, remaining
+}
+
+// EvaluateBetSelections settles each selection with the processor of its market
+func EvaluateBetSelections(selections []cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) []cricket.BetSelection {
+	evaluated := []cricket.BetSelection{}
+	for _, selection := range selections {
+		result, err := Markets.Evaluate(selection.MarketID, selection, matchInfo)
+		if err != nil {
+			log.Printf("Skipping selection %s: %v", selection.Selection, err)
+			continue
+		}
+		evaluated = append(evaluated, result)
+	}
+	return evaluated
+}
+
+// marketSelections creates one selection per priced option of a market. Every
+// selection shares the template's description and the list of available
+// options; optionName labels an option and selectionName describes backing it.
+func marketSelections(template cricket.BetSelection, market cricket.Market, optionName func(odd cricket.Odd) string, selectionName func(odd cricket.Odd) string) []cricket.BetSelection {
+	selections := []cricket.BetSelection{}
+
+	fair := marketFairOdds(market)
+	template.AvailableOptions = []string{}
+	for _, odd := range market.Odds {
+		odds, _ := strconv.ParseFloat(odd.Odds, 64)
+		option := fmt.Sprintf("%s @ %.2f", optionName(odd), odds)
+		if fairOdds, ok := fair[odd.ID]; ok {
+			option += fmt.Sprintf(" (fair %.2f)", fairOdds)
+		}
+		template.AvailableOptions = append(template.AvailableOptions, option)
+	}
+
+	for _, odd := range market.Odds {
+		odds, err := strconv.ParseFloat(odd.Odds, 64)
+		if err != nil || odds <= 0 {
+			continue
+		}
+		selection := template
+		selection.SelectionID = odd.ID
+		selection.Selection = selectionName(odd)
+		selection.Option = odd
+		selection.FairOdds = fair[odd.ID]
+		selection.Closed = market.IsClosed()
+		priceSelection(&selection, odds)
+		selections = append(selections, selection)
+	}
+
+	return selections
+}
+
+// priceSelection fills the odds formats and risk of a selection
+func priceSelection(selection *cricket.BetSelection, decimal float64) {
+	selection.Odds = decimal
+	selection.OddsDecimal = decimal
+	selection.Price, _ = odds.FromLadder(decimal)
+	if !selection.Price.IsZero() {
+		selection.OddsAmerican = selection.Price.Format(odds.American)
+		selection.OddsFractional = selection.Price.Format(odds.Fractional)
+	}
+	if rung, ok := odds.Snap(decimal); ok {
+		// Quote the fraction as the bookmaker does, e.g. 4/6 rather than 2/3
+		selection.OddsFractional = rung.Quote
+	}
+
+	// Set risk assessment based on odds
+	if selection.Odds < 1.5 {
+		selection.RiskAssessment = "Low Risk"
+	} else if selection.Odds < 3.0 {
+		selection.RiskAssessment = "Medium Risk"
+	} else {
+		selection.RiskAssessment = "High Risk"
+	}
+}
+
+// teamOptionName converts option names (1=Home, 2=Away) to team names
+func teamOptionName(name string, matchInfo cricket.DetailedMatchInfo, other string) string {
+	if name == "1" {
+		return matchInfo.HomeTeam
+	} else if name == "2" {
+		return matchInfo.AwayTeam
+	}
+	return other
+}
+
+// overUnderLine reads the side and line of an Over/Under option. Bet365 quotes
+// them as header "Over" and name "6.5", as name "Over" and handicap "20.5", or
+// for a player as header "Over", the player as name and handicap "21.5".
+func overUnderLine(odd cricket.Odd) (string, float64) {
+	side, line := odd.Header, odd.Name
+	switch {
+	case odd.Name == "Over" || odd.Name == "Under":
+		side, line = odd.Name, odd.Handicap
+	case odd.Handicap != "":
+		line = odd.Handicap
+	}
+	value, _ := strconv.ParseFloat(line, 64)
+	return side, value
+}
+
+// overUnderOption labels an Over/Under option, e.g. "Over 6.5"
+func overUnderOption(odd cricket.Odd) string {
+	side, line := overUnderLine(odd)
+	return fmt.Sprintf("%s %.1f", side, line)
+}
+
+// settleOverUnder settles an Over/Under selection against an actual value;
+// landing exactly on a whole-number line is a push
+func settleOverUnder(selection *cricket.BetSelection, actual int) float64 {
+	side, line := overUnderLine(selection.Option)
+	margin := float64(actual) - line
+	if side != "Over" {
+		margin = -margin
+	}
+	selection.Outcome = settlement.Line(margin)
+	return line
+}
+
+// settleTotal settles an Over/Under selection on a total that may not have
+// run its full course, e.g. an innings cut short by rain. Such a total only
+// stands once the line was already passed, which decides both sides;
+// otherwise the selection is void. It reports whether it was settled.
+func settleTotal(selection *cricket.BetSelection, actual int, complete bool, reason string) (float64, bool) {
+	_, line := overUnderLine(selection.Option)
+	if !complete && float64(actual) <= line {
+		voidSelection(selection, fmt.Sprintf("%s with %d against a line of %.1f", reason, actual, line))
+		return line, false
+	}
+	return settleOverUnder(selection, actual), true
+}
+
+// inningsComplete reports whether an innings ran its full course: the side
+// was bowled out or faced all its scheduled overs. A match with a result has
+// a complete first innings unless its overs were cut.
+func inningsComplete(innings cricket.Innings, matchInfo cricket.DetailedMatchInfo) bool {
+	scheduled := matchInfo.Outcome.ScheduledOvers
+	if innings.MaxOvers > 0 && innings.MaxOvers < scheduled {
+		return false
+	}
+	balls := oversToBalls(parseOvers(innings.Overs))
+	return innings.Wickets >= 10 || (scheduled > 0 && balls >= scheduled*6) || matchInfo.Outcome.Decided()
+}
+
+// compareToLine returns ">", "<" or "=" for an actual value against a line
+func compareToLine(actual int, line float64) string {
+	switch {
+	case float64(actual) > line:
+		return ">"
+	case float64(actual) < line:
+		return "<"
+	}
+	return "="
+}
+
+// settleYesNo settles a Yes/No selection against whether the event happened
+func settleYesNo(selection *cricket.BetSelection, happened bool) {
+	selection.Outcome = settlement.WinOrLose((selection.Option.Name == "Yes") == happened)
+}
+
+// voidSelection voids a selection that cannot be settled
+func voidSelection(selection *cricket.BetSelection, reason string) {
+	selection.Outcome = settlement.Outcome{Status: settlement.Void}
+	selection.Evaluation = reason
+}
+
+// hasScorecard marks the selection as not settled when the event has no scorecard
+func hasScorecard(selection *cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) bool {
+	if matchInfo.Scorecard == nil || len(matchInfo.Scorecard.Innings) == 0 {
+		voidSelection(selection, "No scorecard available for this event")
+		return false
+	}
+	return true
+}
+
+// hasDeliveries marks the selection as not settled when the event has no ball-by-ball data
+func hasDeliveries(selection *cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) bool {
+	if matchInfo.Deliveries == nil || matchInfo.Firsts.FirstBall == nil {
+		voidSelection(selection, "No ball-by-ball data available for this event")
+		return false
+	}
+	return true
+}
+
+// topScorer returns the batter with the highest score of the match
+func topScorer(matchInfo cricket.DetailedMatchInfo) (string, int) {
+	best, bestRuns := "", -1
+	for player, stats := range matchInfo.BattingStats {
+		if stats.Runs > bestRuns || (stats.Runs == bestRuns && player < best) {
+			best, bestRuns = player, stats.Runs
+		}
+	}
+	return best, bestRuns
+}
+
+// matchWinnerMarket settles the "To Win the Match" market
+type matchWinnerMarket struct{}
+
+func (matchWinnerMarket) MarketID() string { return "1246" }
+
+func (m matchWinnerMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "To Win the Match",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on which team will win the match",
+		ConfidenceLevel:   "High",
+	}
+
+	teamToWin := func(odd cricket.Odd) string {
+		name := teamOptionName(odd.Name, input.MatchInfo, "Draw/Tie")
+		if odd.Name == "1" || odd.Name == "2" {
+			name += " to win"
+		}
+		return name
+	}
+	market := input.Prematch.Main.SP.ToWinTheMatch
+	for _, odd := range market.Odds {
+		if odd.Name != "1" && odd.Name != "2" {
+			template.TieOffered = true
+		}
+	}
+	return marketSelections(template, market, teamToWin, teamToWin)
+}
+
+func (matchWinnerMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !settledOutcome(&selection, matchInfo) {
+		return selection
+	}
+	outcome := matchInfo.Outcome
+	team := selection.Option.Name == "1" || selection.Option.Name == "2"
+
+	switch outcome.Type {
+	case cricket.ResultTie:
+		// Without a super over a tie wins a priced tie and loses both teams;
+		// when no tie was priced it is a dead heat between the two teams
+		switch {
+		case !team:
+			selection.Outcome = settlement.WinOrLose(true)
+		case selection.TieOffered:
+			selection.Outcome = settlement.WinOrLose(false)
+		default:
+			selection.Outcome = settlement.DeadHeatFor(2, 1)
+		}
+	case cricket.ResultDraw:
+		// A drawn multi-day match wins a priced draw and loses both teams
+		selection.Outcome = settlement.WinOrLose(!team)
+	default:
+		// A super over decides the winner of a tied match
+		selection.Outcome = settlement.WinOrLose(team && teamOption(outcome.Winner, matchInfo) == selection.Option.Name)
+	}
+	selection.Evaluation = fmt.Sprintf("%s (score %s %s - %s %s)",
+		outcome, matchInfo.HomeTeam, matchInfo.Score.Home, matchInfo.Score.Away, matchInfo.AwayTeam)
+
+	return selection
+}
+
+// firstOverRunsMarket settles the "1st Over Total Runs" market
+type firstOverRunsMarket struct{}
+
+func (firstOverRunsMarket) MarketID() string { return "300336" }
+
+func (m firstOverRunsMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "1st Over Total Runs",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on the total number of runs scored in the first over",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.FirstOver.SP.FirstOverTotalRuns, overUnderOption,
+		func(odd cricket.Odd) string { return fmt.Sprintf("%s %s runs in first over", odd.Header, odd.Name) })
+}
+
+func (firstOverRunsMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+	innings := matchInfo.Scorecard.Innings[0]
+	if len(innings.OverRuns) == 0 {
+		voidSelection(&selection, "The scorecard has no over-by-over runs")
+		return selection
+	}
+	firstOverRuns := innings.OverRuns[0]
+
+	// The over must be bowled in full unless the innings ended inside it
+	complete := oversToBalls(parseOvers(innings.Overs)) >= 6 || innings.Wickets >= 10
+	line, settled := settleTotal(&selection, firstOverRuns, complete, "The first over was not completed")
+	if settled {
+		selection.Evaluation = fmt.Sprintf("First over had %d runs (%s %.1f)",
+			firstOverRuns, compareToLine(firstOverRuns, line), line)
+	}
+
+	return selection
+}
+
+// firstInningsScoreMarket settles the "1st Innings Score" market
+type firstInningsScoreMarket struct{}
+
+func (firstInningsScoreMarket) MarketID() string { return "300338" }
+
+func (m firstInningsScoreMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "1st Innings Score",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether the first innings score will be over or under a specific value",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.Innings1.SP.FirstInningsScore, overUnderOption,
+		func(odd cricket.Odd) string { return fmt.Sprintf("%s %s runs in first innings", odd.Header, odd.Name) })
+}
+
+func (firstInningsScoreMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+	innings := matchInfo.Scorecard.Innings[0]
+	firstInningsScore := innings.Runs
+
+	line, settled := settleTotal(&selection, firstInningsScore, inningsComplete(innings, matchInfo),
+		"The first innings was cut short")
+	if settled {
+		selection.Evaluation = fmt.Sprintf("First innings score was %d (%s %.1f)",
+			firstInningsScore, compareToLine(firstInningsScore, line), line)
+	}
+
+	return selection
+}
+
+// fiftyToBeScoredMarket settles the "A Fifty to be Scored" market
+type fiftyToBeScoredMarket struct{}
+
+func (fiftyToBeScoredMarket) MarketID() string { return "30201" }
+
+func (m fiftyToBeScoredMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "A Fifty to be Scored",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether any player will score fifty or more runs in the match",
+		ConfidenceLevel:   "High",
+	}
+
+	return marketSelections(template, input.Prematch.Match.SP.AFiftyToBeScored,
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string {
+			if odd.Name == "Yes" {
+				return "Yes - A fifty will be scored"
+			}
+			return "No - A fifty will not be scored"
+		})
+}
+
+func (fiftyToBeScoredMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+
+	// Check if any player scored a fifty
+	var fiftyScorers []string
+
+	for player, stats := range matchInfo.BattingStats {
+		if stats.Runs >= 50 {
+			fiftyScorers = append(fiftyScorers, fmt.Sprintf("%s (%d)", player, stats.Runs))
+		}
+	}
+
+	sort.Strings(fiftyScorers)
+	settleYesNo(&selection, len(fiftyScorers) > 0)
+	if len(fiftyScorers) > 0 {
+		selection.Evaluation = fmt.Sprintf("A fifty was scored. Players with 50+ runs: %s",
+			strings.Join(fiftyScorers, ", "))
+	} else {
+		selection.Evaluation = "No player scored fifty or more runs in the match"
+	}
+
+	return selection
+}
+
+// superOverMarket settles the "To Go to Super Over" market
+type superOverMarket struct{}
+
+func (superOverMarket) MarketID() string { return "300012" }
+
+func (m superOverMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "To Go to Super Over",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether the match will go to a super over",
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, input.Prematch.Match.SP.ToGoToSuperOver,
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string {
+			if odd.Name == "Yes" {
+				return "Yes - Match will go to super over"
+			}
+			return "No - Match will not go to super over"
+		})
+}
+
+func (superOverMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !settledOutcome(&selection, matchInfo) {
+		return selection
+	}
+
+	// A tie sends the match to a super over, whether or not one could be bowled
+	outcome := matchInfo.Outcome
+	tied := outcome.Type == cricket.ResultTie || outcome.Type == cricket.ResultSuperOver
+	settleYesNo(&selection, tied)
+	switch {
+	case outcome.SuperOver != nil:
+		selection.Evaluation = fmt.Sprintf("The match went to a super over as the scores were tied (%s)", outcome)
+	case tied:
+		selection.Evaluation = "The scores were tied, so the match went to a super over, though none was bowled"
+	default:
+		selection.Evaluation = fmt.Sprintf("The match did not go to a super over. Final score: %s %s - %s %s",
+			matchInfo.HomeTeam, matchInfo.Score.Home, matchInfo.Score.Away, matchInfo.AwayTeam)
+	}
+
+	return selection
+}
+
+// mostSixesMarket settles the "Most Match Sixes" market
+type mostSixesMarket struct{}
+
+func (mostSixesMarket) MarketID() string { return "1006" }
+
+func (m mostSixesMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Most Match Sixes",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on which team will hit the most sixes in the match",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.Match.SP.MostMatchSixes,
+		func(odd cricket.Odd) string { return teamOptionName(odd.Name, input.MatchInfo, "Tie") },
+		func(odd cricket.Odd) string {
+			if odd.Name == "1" || odd.Name == "2" {
+				return fmt.Sprintf("%s to hit most sixes", teamOptionName(odd.Name, input.MatchInfo, ""))
+			}
+			return "Both teams to hit the same number of sixes"
+		})
+}
+
+func (mostSixesMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+
+	// Count sixes for each team
+	homeSixes := 0
+	awaySixes := 0
+
+	for _, stats := range matchInfo.BattingStats {
+		switch stats.Team {
+		case matchInfo.HomeTeam:
+			homeSixes += stats.Sixes
+		case matchInfo.AwayTeam:
+			awaySixes += stats.Sixes
+		}
+	}
+
+	if awaySixes > homeSixes {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "2")
+		selection.Evaluation = fmt.Sprintf("%s hit more sixes (%d) than %s (%d)",
+			matchInfo.AwayTeam, awaySixes, matchInfo.HomeTeam, homeSixes)
+	} else if homeSixes > awaySixes {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "1")
+		selection.Evaluation = fmt.Sprintf("%s hit more sixes (%d) than %s (%d)",
+			matchInfo.HomeTeam, homeSixes, matchInfo.AwayTeam, awaySixes)
+	} else {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "Tie")
+		selection.Evaluation = fmt.Sprintf("Both teams hit the same number of sixes (%d)", homeSixes)
+	}
+
+	return selection
+}
+
+// mostFoursMarket settles the "Most Match Fours" market
+type mostFoursMarket struct{}
+
+func (mostFoursMarket) MarketID() string { return "300029" }
+
+func (m mostFoursMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Most Match Fours",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on which team will hit the most fours in the match",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.Match.SP.MostMatchFours,
+		func(odd cricket.Odd) string { return teamOptionName(odd.Name, input.MatchInfo, "Tie") },
+		func(odd cricket.Odd) string {
+			if odd.Name == "1" || odd.Name == "2" {
+				return fmt.Sprintf("%s to hit most fours", teamOptionName(odd.Name, input.MatchInfo, ""))
+			}
+			return "Both teams to hit the same number of fours"
+		})
+}
+
+func (mostFoursMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+
+	// Count fours for each team
+	homeFours := 0
+	awayFours := 0
+
+	for _, stats := range matchInfo.BattingStats {
+		switch stats.Team {
+		case matchInfo.HomeTeam:
+			homeFours += stats.Boundaries
+		case matchInfo.AwayTeam:
+			awayFours += stats.Boundaries
+		}
+	}
+
+	if awayFours > homeFours {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "2")
+		selection.Evaluation = fmt.Sprintf("%s hit more fours (%d) than %s (%d)",
+			matchInfo.AwayTeam, awayFours, matchInfo.HomeTeam, homeFours)
+	} else if homeFours > awayFours {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "1")
+		selection.Evaluation = fmt.Sprintf("%s hit more fours (%d) than %s (%d)",
+			matchInfo.HomeTeam, homeFours, matchInfo.AwayTeam, awayFours)
+	} else {
+		selection.Outcome = settlement.WinOrLose(selection.Option.Name == "Tie")
+		selection.Evaluation = fmt.Sprintf("Both teams hit the same number of fours (%d)", homeFours)
+	}
+
+	return selection
+}
+
+// hundredToBeScoredMarket settles the "A Hundred to be Scored" market
+type hundredToBeScoredMarket struct{}
+
+func (hundredToBeScoredMarket) MarketID() string { return "30202" }
+
+func (m hundredToBeScoredMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "A Hundred to be Scored",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether any player will score a century in the match",
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, input.Prematch.Match.SP.AHundredToBeScored,
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string {
+			if odd.Name == "Yes" {
+				return "Yes - A hundred will be scored"
+			}
+			return "No - A hundred will not be scored"
+		})
+}
+
+func (hundredToBeScoredMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+
+	// Check if any player scored a hundred
+	var centuryScorers []string
+
+	for player, stats := range matchInfo.BattingStats {
+		if stats.Runs >= 100 {
+			centuryScorers = append(centuryScorers, fmt.Sprintf("%s (%d)", player, stats.Runs))
+		}
+	}
+
+	sort.Strings(centuryScorers)
+	settleYesNo(&selection, len(centuryScorers) > 0)
+	if len(centuryScorers) > 0 {
+		selection.Evaluation = fmt.Sprintf("A hundred was scored. Players with 100+ runs: %s",
+			strings.Join(centuryScorers, ", "))
+	} else {
+		player, runs := topScorer(matchInfo)
+		selection.Evaluation = fmt.Sprintf("No player scored a hundred in the match. Highest score was by %s (%d runs)",
+			player, runs)
+	}
+
+	return selection
+}
+
+// raceToTenRunsMarket settles the "Race to 10 Runs" market between two batters
+type raceToTenRunsMarket struct{}
+
+func (raceToTenRunsMarket) MarketID() string { return "30052" }
+
+func (m raceToTenRunsMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Race to 10 Runs",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on which of two batters reaches 10 runs first",
+		ConfidenceLevel:   "Medium",
+	}
+
+	raceWinner := func(odd cricket.Odd) string {
+		first, second, _ := strings.Cut(odd.Name, " v ")
+		switch odd.Header {
+		case "1":
+			return first
+		case "2":
+			return second
+		}
+		return odd.Header
+	}
+	return marketSelections(template, input.Prematch.OtherMarket("race_to_10_runs"),
+		func(odd cricket.Odd) string { return fmt.Sprintf("%s: %s", odd.Name, raceWinner(odd)) },
+		func(odd cricket.Odd) string {
+			if odd.Header == "Neither" {
+				return fmt.Sprintf("Neither of %s to reach 10 runs", odd.Name)
+			}
+			return fmt.Sprintf("%s to reach 10 runs first (%s)", raceWinner(odd), odd.Name)
+		})
+}
+
+func (raceToTenRunsMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasDeliveries(&selection, matchInfo) {
+		return selection
+	}
+	first, second, _ := strings.Cut(selection.Option.Name, " v ")
+
+	winner := "Neither"
+	runs := make(map[string]int)
+	for _, innings := range matchInfo.Deliveries.Innings {
+		for _, delivery := range innings.Deliveries {
+			runs[delivery.Batter] += delivery.Runs
+			if winner == "Neither" && runs[delivery.Batter] >= 10 {
+				if delivery.Batter == first {
+					winner = "1"
+				} else if delivery.Batter == second {
+					winner = "2"
+				}
+			}
+		}
+	}
+
+	selection.Outcome = settlement.WinOrLose(selection.Option.Header == winner)
+	switch winner {
+	case "1":
+		selection.Evaluation = fmt.Sprintf("%s reached 10 runs first (%s finished on %d)", first, second, runs[second])
+	case "2":
+		selection.Evaluation = fmt.Sprintf("%s reached 10 runs first (%s finished on %d)", second, first, runs[first])
+	default:
+		selection.Evaluation = fmt.Sprintf("Neither batter reached 10 runs (%s %d, %s %d)",
+			first, runs[first], second, runs[second])
+	}
+
+	return selection
+}
+
+// firstBallDotMarket settles the "First Match Ball to be A Dot" market
+type firstBallDotMarket struct{}
+
+func (firstBallDotMarket) MarketID() string { return "300156" }
+
+func (m firstBallDotMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "First Match Ball to be A Dot",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether no run is scored off the first ball of the match",
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, input.Prematch.OtherMarket("first_match_ball_to_be_a_dot"),
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string {
+			if odd.Name == "Yes" {
+				return "Yes - First ball will be a dot"
+			}
+			return "No - First ball will not be a dot"
+		})
+}
+
+func (firstBallDotMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasDeliveries(&selection, matchInfo) {
+		return selection
+	}
+	first := matchInfo.Firsts.FirstBall
+
+	settleYesNo(&selection, first.Total() == 0)
+	selection.Evaluation = fmt.Sprintf("First ball of the match, %s to %s, went for %d run(s)",
+		first.Bowler, first.Batter, first.Total())
+
+	return selection
+}
+
+// firstScoringShotMarket settles the "1st Scoring Shot of the Match" market
+type firstScoringShotMarket struct{}
+
+func (firstScoringShotMarket) MarketID() string { return "300126" }
+
+func (m firstScoringShotMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "1st Scoring Shot of the Match",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on the runs scored off the bat with the first scoring shot",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.OtherMarket("1st_scoring_shot_of_the_match"),
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string { return fmt.Sprintf("First scoring shot to be: %s", odd.Name) })
+}
+
+func (firstScoringShotMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasDeliveries(&selection, matchInfo) {
+		return selection
+	}
+	shot := matchInfo.Firsts.FirstScoringShot
+	if shot == nil {
+		voidSelection(&selection, "No run was scored off the bat in the match")
+		return selection
+	}
+
+	outcome := "Other"
+	switch shot.Runs {
+	case 1:
+		outcome = "Single"
+	case 2:
+		outcome = "Two"
+	case 3:
+		outcome = "Three"
+	case 4:
+		outcome = "Four"
+	case 6:
+		outcome = "Six"
+	}
+
+	selection.Outcome = settlement.WinOrLose(selection.Option.Name == outcome)
+	selection.Evaluation = fmt.Sprintf("First scoring shot was a %s by %s off %s (%d.%d)",
+		strings.ToLower(outcome), shot.Batter, shot.Bowler, shot.Over-1, shot.Ball)
+
+	return selection
+}
+
+// mostRunsInOverMarket settles the "Most Runs in a Single Over" market
+type mostRunsInOverMarket struct{}
+
+func (mostRunsInOverMarket) MarketID() string { return "300171" }
+
+func (m mostRunsInOverMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Most Runs in a Single Over",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on the most runs conceded in any single over of the match",
+		ConfidenceLevel:   "Medium",
+	}
+
+	return marketSelections(template, input.Prematch.OtherMarket("most_runs_in_a_single_over"), overUnderOption,
+		func(odd cricket.Odd) string {
+			return fmt.Sprintf("%s runs in the most expensive over", overUnderOption(odd))
+		})
+}
+
+func (mostRunsInOverMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasDeliveries(&selection, matchInfo) {
+		return selection
+	}
+
+	mostRuns, team, over := 0, "", 0
+	for _, innings := range matchInfo.Scorecard.Innings {
+		if innings.SuperOver {
+			continue
+		}
+		for i, runs := range innings.OverRuns {
+			if runs > mostRuns {
+				mostRuns, team, over = runs, innings.BattingTeam, i+1
+			}
+		}
+	}
+
+	line, settled := settleTotal(&selection, mostRuns, matchInfo.Outcome.Decided(), matchInfo.Outcome.String())
+	if settled {
+		selection.Evaluation = fmt.Sprintf("Most runs in an over was %d (over %d of the %s innings, line %.1f)",
+			mostRuns, over, team, line)
+	}
+
+	return selection
+}
+
+// sixBoundariesInOverMarket settles the "Six Boundaries in an Over - Match" market
+type sixBoundariesInOverMarket struct{}
+
+func (sixBoundariesInOverMarket) MarketID() string { return "300200" }
+
+func (m sixBoundariesInOverMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Six Boundaries in an Over - Match",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on whether six boundaries are hit in any single over of the match",
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, input.Prematch.OtherMarket("six_boundaries_in_an_over_match"),
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string {
+			if odd.Name == "Yes" {
+				return "Yes - Six boundaries will be hit in an over"
+			}
+			return "No - Six boundaries will not be hit in an over"
+		})
+}
+
+func (sixBoundariesInOverMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasDeliveries(&selection, matchInfo) {
+		return selection
+	}
+
+	mostBoundaries := 0
+	for _, innings := range matchInfo.Deliveries.Innings {
+		if innings.SuperOver {
+			continue
+		}
+		boundaries := make(map[int]int)
+		for _, delivery := range innings.Deliveries {
+			if delivery.Runs == 4 || delivery.Runs == 6 {
+				boundaries[delivery.Over]++
+				mostBoundaries = max(mostBoundaries, boundaries[delivery.Over])
+			}
+		}
+	}
+
+	settleYesNo(&selection, mostBoundaries >= 6)
+	selection.Evaluation = fmt.Sprintf("Most boundaries hit in a single over was %d", mostBoundaries)
+
+	return selection
+}
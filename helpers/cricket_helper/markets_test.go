@@ -0,0 +1,67 @@
+package cricket_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func TestMatchWinnerTie(t *testing.T) {
+	tests := []struct {
+		name       string
+		option     string
+		tieOffered bool
+		want       settlement.Outcome
+	}{
+		{"team without a priced tie", "1", false, settlement.DeadHeatFor(2, 1)},
+		{"team with a priced tie", "2", true, settlement.WinOrLose(false)},
+		{"tie option", "Draw/Tie", true, settlement.WinOrLose(true)},
+	}
+
+	matchInfo := cricket.DetailedMatchInfo{
+		HomeTeam: "H",
+		AwayTeam: "A",
+		Outcome:  cricket.MatchOutcome{Type: cricket.ResultTie},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := cricket.BetSelection{Option: cricket.Odd{Name: test.option}, TieOffered: test.tieOffered}
+			if got := (matchWinnerMarket{}).Evaluate(selection, matchInfo).Outcome; got != test.want {
+				t.Errorf("option %q settled %s, want %s", test.option, got, test.want)
+			}
+		})
+	}
+}
+
+func TestMatchHandicapWithoutScorecard(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		option  string
+		outcome cricket.MatchOutcome
+		want    settlement.Status
+	}{
+		{"winner by runs covers", "1", "-12.5 runs/-4.5 wkts",
+			cricket.MatchOutcome{Type: cricket.ResultWin, Winner: "H", Margin: 30, MarginUnit: cricket.MarginRuns}, settlement.Win},
+		{"loser by runs inside the line", "2", "+12.5 runs/+4.5 wkts",
+			cricket.MatchOutcome{Type: cricket.ResultWin, Winner: "H", Margin: 10, MarginUnit: cricket.MarginRuns}, settlement.Win},
+		{"winner by wickets short of the line", "2", "-12.5 runs/-4.5 wkts",
+			cricket.MatchOutcome{Type: cricket.ResultWin, Winner: "A", Margin: 3, MarginUnit: cricket.MarginWickets}, settlement.Lose},
+		{"tie", "1", "+12.5 runs/+4.5 wkts",
+			cricket.MatchOutcome{Type: cricket.ResultTie}, settlement.Win},
+		{"no result", "1", "+12.5 runs/+4.5 wkts",
+			cricket.MatchOutcome{Type: cricket.ResultNoResult}, settlement.Void},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matchInfo := cricket.DetailedMatchInfo{HomeTeam: "H", AwayTeam: "A", Outcome: test.outcome}
+			selection := cricket.BetSelection{Option: cricket.Odd{Header: test.header, Name: test.option}}
+			got := (matchHandicapMarket{}).Evaluate(selection, matchInfo)
+			if got.Outcome.Status != test.want {
+				t.Errorf("%s %s settled %s (%s), want %s", test.header, test.option, got.Outcome, got.Evaluation, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package cricket_helper
+
+import (
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+)
+
+// Result time statuses of matches that ended without being played out
+var unfinishedStatuses = map[string]bool{
+	"4": true, // Postponed
+	"5": true, // Cancelled
+	"7": true, // Interrupted
+	"8": true, // Abandoned
+}
+
+// DeriveOutcome works out the official result of a match from its score. The
+// scorecard gives the batting order; without it the higher score of a
+// limited-overs match wins by runs. A match that stopped early has no result
+// once a ball was bowled and is abandoned before that.
+func DeriveOutcome(result cricket.CricketMatchResult, scorecard *cricket.Scorecard, score cricket.MatchScore) cricket.MatchOutcome {
+	outcome := cricket.MatchOutcome{}
+	main, superOvers := []cricket.Innings{}, []cricket.Innings{}
+	if scorecard != nil {
+		outcome.ScheduledOvers = scorecard.ScheduledOvers
+		for _, innings := range scorecard.Innings {
+			if innings.SuperOver {
+				superOvers = append(superOvers, innings)
+				continue
+			}
+			main = append(main, innings)
+			if innings.MaxOvers > 0 && innings.MaxOvers < scorecard.ScheduledOvers {
+				outcome.ReducedOvers = true
+			}
+		}
+	}
+
+	status := ""
+	if scorecard != nil {
+		status = strings.ToLower(strings.TrimSpace(scorecard.Status))
+	}
+	unfinished := unfinishedStatuses[result.TimeStatus] || status == string(cricket.ResultNoResult) ||
+		status == string(cricket.ResultAbandoned)
+	if scorecard != nil && len(main) < 2 {
+		// The side batting second never got its innings
+		unfinished = true
+	}
+	if unfinished {
+		outcome.Type = cricket.ResultAbandoned
+		for _, team := range []cricket.TeamScore{score.Home, score.Away} {
+			for _, innings := range team.Innings {
+				if innings.Runs > 0 || innings.Wickets > 0 || (innings.Overs != "" && oversToBalls(parseOvers(innings.Overs)) > 0) {
+					outcome.Type = cricket.ResultNoResult
+				}
+			}
+		}
+		if status == string(cricket.ResultNoResult) {
+			outcome.Type = cricket.ResultNoResult
+		}
+		return outcome
+	}
+
+	if score.MultiInnings() {
+		last := ""
+		if len(main) > 0 {
+			last = main[len(main)-1].BattingTeam
+		}
+		return multiDayOutcome(outcome, score, result.Home.Name, result.Away.Name, last)
+	}
+
+	if scorecard == nil {
+		homeRuns, awayRuns := score.Home.Runs(), score.Away.Runs()
+		outcome.MarginUnit = cricket.MarginRuns
+		switch {
+		case homeRuns > awayRuns:
+			outcome.Type, outcome.Winner, outcome.Margin = cricket.ResultWin, result.Home.Name, homeRuns-awayRuns
+		case awayRuns > homeRuns:
+			outcome.Type, outcome.Winner, outcome.Margin = cricket.ResultWin, result.Away.Name, awayRuns-homeRuns
+		default:
+			outcome.Type = cricket.ResultTie
+		}
+		if outcome.Type == cricket.ResultWin {
+			winner, loser := score.Home, score.Away
+			if outcome.Winner == result.Away.Name {
+				winner, loser = loser, winner
+			}
+			chaseOutcome(&outcome, winner, loser)
+		}
+		return outcome
+	}
+
+	first, second := main[0], main[1]
+	outcome.Target = first.Runs + 1
+	if second.Target > 0 {
+		outcome.Target, outcome.DLS = second.Target, true
+	}
+	switch {
+	case second.Runs >= outcome.Target:
+		// The chasing side wins by the wickets it had left
+		outcome.Type, outcome.Winner = cricket.ResultWin, second.BattingTeam
+		outcome.Margin, outcome.MarginUnit = 10-second.Wickets, cricket.MarginWickets
+	case second.Runs < outcome.Target-1:
+		outcome.Type, outcome.Winner = cricket.ResultWin, first.BattingTeam
+		outcome.Margin, outcome.MarginUnit = outcome.Target-1-second.Runs, cricket.MarginRuns
+	default:
+		outcome.Type = cricket.ResultTie
+		outcome.SuperOver = superOverResult(superOvers)
+		if outcome.SuperOver != nil && outcome.SuperOver.Winner != "" {
+			outcome.Type, outcome.Winner = cricket.ResultSuperOver, outcome.SuperOver.Winner
+		}
+	}
+	return outcome
+}
+
+// chaseOutcome gives the margin in wickets when the score shows the winner
+// batted second. A loser that was not bowled out batted its full overs, so a
+// winner that faced fewer balls and still had wickets left reached its target
+// early; otherwise the batting order is unknown and the margin stays in runs.
+func chaseOutcome(outcome *cricket.MatchOutcome, winner, loser cricket.TeamScore) {
+	won, _ := winner.Last()
+	lost, _ := loser.Last()
+	if won.Wickets < 0 || lost.Wickets < 0 || won.AllOut() || lost.AllOut() || won.Overs == "" || lost.Overs == "" {
+		return
+	}
+	if oversToBalls(parseOvers(won.Overs)) < oversToBalls(parseOvers(lost.Overs)) {
+		outcome.Margin, outcome.MarginUnit = 10-won.Wickets, cricket.MarginWickets
+		outcome.Target = lost.Runs + 1
+	}
+}
+
+// closedInnings reports whether an innings ended before the match did: all
+// out, declared, or given in a multi-day score as bare runs, which means all out
+func closedInnings(innings cricket.InningsScore) bool {
+	return innings.Wickets < 0 || innings.AllOut() || innings.Declared
+}
+
+// multiDayOutcome decides a match in which the teams bat twice. Every innings
+// but the last of the match is closed, so without a scorecard the side with
+// an open innings batted last. When that side falls short with wickets left
+// the match is drawn; a side that batted once and still outscored the other
+// wins by an innings. The home side is checked first, so a score that shows
+// both innings open always settles the same way.
+func multiDayOutcome(outcome cricket.MatchOutcome, score cricket.MatchScore, home, away, last string) cricket.MatchOutcome {
+	if last == "" {
+		if innings, ok := score.Home.Last(); ok && !closedInnings(innings) {
+			last = home
+		} else if innings, ok := score.Away.Last(); ok && !closedInnings(innings) {
+			last = away
+		}
+	}
+
+	leader, trailer := home, away
+	leaderScore, trailerScore := score.Home, score.Away
+	if trailerScore.Runs() > leaderScore.Runs() {
+		leader, trailer = trailer, leader
+		leaderScore, trailerScore = trailerScore, leaderScore
+	}
+	lastScore := leaderScore
+	if last == trailer {
+		lastScore = trailerScore
+	}
+	lastInnings, _ := lastScore.Last()
+	open := last != "" && !closedInnings(lastInnings)
+	if last != "" {
+		// The side batting last needed one run more than the other side's lead
+		opponent := leaderScore
+		if last == leader {
+			opponent = trailerScore
+		}
+		outcome.Target = opponent.Runs() - (lastScore.Runs() - lastInnings.Runs) + 1
+	}
+
+	switch {
+	case leaderScore.Runs() == trailerScore.Runs() && open:
+		outcome.Type = cricket.ResultDraw
+	case leaderScore.Runs() == trailerScore.Runs():
+		outcome.Type = cricket.ResultTie
+	case last == leader:
+		// The side batting last reached its target with wickets left
+		outcome.Type, outcome.Winner = cricket.ResultWin, leader
+		outcome.Margin, outcome.MarginUnit = 10-lastInnings.Wickets, cricket.MarginWickets
+	case last == trailer && open:
+		outcome.Type = cricket.ResultDraw
+	default:
+		outcome.Type, outcome.Winner = cricket.ResultWin, leader
+		outcome.Margin, outcome.MarginUnit = leaderScore.Runs()-trailerScore.Runs(), cricket.MarginRuns
+		if len(leaderScore.Innings) == 1 && len(trailerScore.Innings) > 1 {
+			outcome.MarginUnit = cricket.MarginInnings
+		}
+	}
+	return outcome
+}
+
+// superOverResult decides a tie from its super overs, bowled in pairs. A tied
+// super over is replayed, so the last pair decides. It is nil without any.
+func superOverResult(innings []cricket.Innings) *cricket.SuperOverResult {
+	if len(innings) < 2 {
+		return nil
+	}
+	result := &cricket.SuperOverResult{Played: len(innings) / 2}
+	last := innings[(result.Played-1)*2 : result.Played*2]
+	result.Runs = map[string]int{last[0].BattingTeam: last[0].Runs, last[1].BattingTeam: last[1].Runs}
+	switch {
+	case last[0].Runs > last[1].Runs:
+		result.Winner = last[0].BattingTeam
+	case last[1].Runs > last[0].Runs:
+		result.Winner = last[1].BattingTeam
+	}
+	return result
+}
+
+// teamOption returns the option of a team, "1" for home and "2" for away
+func teamOption(team string, matchInfo cricket.DetailedMatchInfo) string {
+	switch team {
+	case matchInfo.HomeTeam:
+		return "1"
+	case matchInfo.AwayTeam:
+		return "2"
+	}
+	return ""
+}
+
+// settledOutcome voids a selection when the match produced no result and
+// reports whether it can be settled
+func settledOutcome(selection *cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) bool {
+	if !matchInfo.Outcome.Decided() {
+		voidSelection(selection, matchInfo.Outcome.String())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package cricket_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+)
+
+func TestDeriveOutcomeFromScore(t *testing.T) {
+	tests := []struct {
+		name string
+		ss   string
+		want string
+	}{
+		{"higher score without overs", "117-217", "A won by 100 runs"},
+		{"chase won with balls to spare", "117/9 (20) - 218/5 (18.3)", "A won by 5 wickets"},
+		{"home chase won early", "151/4 (17.2) - 150/7 (20)", "H won by 6 wickets"},
+		{"winner bowled out in fewer overs", "180/10 (18) - 150/7 (20)", "H won by 30 runs"},
+		{"winner bowled out on last ball", "151/10 (19.5) - 150/7 (20)", "H won by 1 run"},
+		{"loser bowled out", "180/6 (20) - 120/10 (16.4)", "H won by 60 runs"},
+		{"tie", "160/8 (20) - 160/6 (20)", "Match tied"},
+		{"innings win", "500/7d - 150 & 200", "H won by an innings and 150 runs"},
+		{"draw with wickets left", "350 & 210/8d - 289 & 150/6", "Match drawn"},
+		{"both last innings open", "350 & 210/8 - 289 & 150/6", "H won by 2 wickets"},
+	}
+
+	result := cricket.CricketMatchResult{TimeStatus: "3"}
+	result.Home.Name, result.Away.Name = "H", "A"
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score, err := ParseMatchScore(test.ss)
+			if err != nil {
+				t.Fatalf("ParseMatchScore(%q): %v", test.ss, err)
+			}
+			if got := DeriveOutcome(result, nil, score).String(); got != test.want {
+				t.Errorf("DeriveOutcome(%q) = %q, want %q", test.ss, got, test.want)
+			}
+		})
+	}
+}
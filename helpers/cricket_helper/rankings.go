@@ -0,0 +1,261 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func init() {
+	Markets.Register(rankingMarket{
+		id:          "1241",
+		name:        "Team - Top Batter",
+		description: "Bet on which batter will score the most runs for their team",
+		stat:        "runs",
+		teams:       true,
+		market:      func(p cricket.CricketPrematchResult) cricket.Market { return p.Main.SP.TeamTopBatter },
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Runs },
+	})
+	Markets.Register(rankingMarket{
+		id:          "1242",
+		name:        "Team - Top Bowler",
+		description: "Bet on which bowler will take the most wickets for their team",
+		stat:        "wickets",
+		teams:       true,
+		market:      otherMarket("team_top_bowler"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BowlingStats[player].Wickets },
+	})
+	Markets.Register(rankingMarket{
+		id:          "30245",
+		name:        "Top Match Batter",
+		description: "Bet on which batter will score the most runs in the match",
+		stat:        "runs",
+		market:      otherMarket("top_match_batter"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Runs },
+	})
+	Markets.Register(rankingMarket{
+		id:          "30246",
+		name:        "Top Match Bowler",
+		description: "Bet on which bowler will take the most wickets in the match",
+		stat:        "wickets",
+		market:      otherMarket("top_match_bowler"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BowlingStats[player].Wickets },
+	})
+	Markets.Register(rankingMarket{
+		id:          "300014",
+		name:        "Player to Score Most Match Sixes",
+		description: "Bet on which player will hit the most sixes in the match",
+		stat:        "sixes",
+		market:      otherMarket("player_to_score_most_match_6s"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Sixes },
+	})
+	Markets.Register(rankingMarket{
+		id:          "300184",
+		name:        "Player to Score Most Sixes - Team",
+		description: "Bet on which player will hit the most sixes for their team",
+		stat:        "sixes",
+		teams:       true,
+		market:      otherMarket("player_to_score_most_6s_team"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Sixes },
+	})
+	Markets.Register(rankingMarket{
+		id:          "300186",
+		name:        "Player to Score Most Match Fours",
+		description: "Bet on which player will hit the most fours in the match",
+		stat:        "fours",
+		market:      otherMarket("player_to_score_most_match_4s"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Boundaries },
+	})
+	Markets.Register(rankingMarket{
+		id:          "300187",
+		name:        "Player to Score Most Fours - Team",
+		description: "Bet on which player will hit the most fours for their team",
+		stat:        "fours",
+		teams:       true,
+		market:      otherMarket("player_to_score_most_match_4s_team"),
+		value:       func(m cricket.DetailedMatchInfo, player string) int { return m.BattingStats[player].Boundaries },
+	})
+	Markets.Register(playerOfTheMatchMarket{})
+}
+
+// otherMarket reads a market from the "others" section of the prematch odds
+func otherMarket(key string) func(p cricket.CricketPrematchResult) cricket.Market {
+	return func(p cricket.CricketPrematchResult) cricket.Market { return p.OtherMarket(key) }
+}
+
+// rankingMarket settles a market won by the player who leads a statistic, e.g.
+// the most runs for a team. Tied leaders are settled under dead-heat rules.
+type rankingMarket struct {
+	id          string
+	name        string
+	description string
+	stat        string // Unit of the statistic used in the evaluation, e.g. "runs"
+	teams       bool   // Options are ranked within their own team rather than the match
+	market      func(p cricket.CricketPrematchResult) cricket.Market
+	value       func(matchInfo cricket.DetailedMatchInfo, player string) int
+}
+
+func (r rankingMarket) MarketID() string { return r.id }
+
+func (r rankingMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            r.name,
+		MarketID:          r.id,
+		MarketDescription: r.description,
+		ConfidenceLevel:   "Low",
+	}
+
+	market := r.market(input.Prematch)
+	if r.teams {
+		market = labelTeamPlayers(market)
+	}
+	playerName := func(odd cricket.Odd) string {
+		if r.teams {
+			return fmt.Sprintf("%s (%s)", odd.Name, teamOptionName(odd.Header, input.MatchInfo, odd.Header))
+		}
+		return odd.Name
+	}
+	return marketSelections(template, market, playerName,
+		func(odd cricket.Odd) string { return fmt.Sprintf("%s to have the most %s", playerName(odd), r.stat) })
+}
+
+func (r rankingMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+	player := selection.Option.Name
+
+	// Every member of the XI is ranked, including those who did not bat or bowl
+	sheets := []cricket.TeamSheet{matchInfo.Scorecard.Home, matchInfo.Scorecard.Away}
+	scope := "the match"
+	if r.teams {
+		switch selection.Option.Header {
+		case "1":
+			sheets = sheets[:1]
+		case "2":
+			sheets = sheets[1:]
+		}
+		scope = sheets[0].Name
+	}
+	values := make(map[string]int)
+	for _, sheet := range sheets {
+		for _, name := range sheet.Players {
+			values[name] = r.value(matchInfo, name)
+		}
+	}
+
+	if _, ok := values[player]; !ok {
+		voidSelection(&selection, fmt.Sprintf("%s was not in the playing XI for %s", player, scope))
+		return selection
+	}
+
+	leaders, best := rankLeaders(values)
+	if best == 0 {
+		voidSelection(&selection, fmt.Sprintf("No player recorded any %s for %s", r.stat, scope))
+		return selection
+	}
+
+	if !slices.Contains(leaders, player) {
+		selection.Outcome = settlement.WinOrLose(false)
+		selection.Evaluation = fmt.Sprintf("%s led %s with %d %s; %s had %d",
+			strings.Join(leaders, ", "), scope, best, r.stat, player, values[player])
+		return selection
+	}
+
+	selection.Outcome = settlement.DeadHeatFor(len(leaders), 1)
+	if len(leaders) == 1 {
+		selection.Evaluation = fmt.Sprintf("%s led %s with %d %s", player, scope, best, r.stat)
+	} else {
+		selection.Evaluation = fmt.Sprintf("Dead heat: %s tied for %s with %d %s, stake divided by %d",
+			strings.Join(leaders, ", "), scope, best, r.stat, len(leaders))
+	}
+	return selection
+}
+
+// playerOfTheMatchMarket settles the "Player of the Match" market
+type playerOfTheMatchMarket struct{}
+
+func (playerOfTheMatchMarket) MarketID() string { return "346" }
+
+func (m playerOfTheMatchMarket) Process(input cricket.MarketInput) []cricket.BetSelection {
+	template := cricket.BetSelection{
+		Market:            "Player of the Match",
+		MarketID:          m.MarketID(),
+		MarketDescription: "Bet on which player will be named player of the match",
+		ConfidenceLevel:   "Low",
+	}
+
+	return marketSelections(template, input.Prematch.OtherMarket("player_of_the_match"),
+		func(odd cricket.Odd) string { return odd.Name },
+		func(odd cricket.Odd) string { return fmt.Sprintf("%s to be player of the match", odd.Name) })
+}
+
+func (playerOfTheMatchMarket) Evaluate(selection cricket.BetSelection, matchInfo cricket.DetailedMatchInfo) cricket.BetSelection {
+	if !hasScorecard(&selection, matchInfo) {
+		return selection
+	}
+	awarded := matchInfo.Scorecard.PlayerOfTheMatch
+	if len(awarded) == 0 {
+		voidSelection(&selection, "No player of the match was named")
+		return selection
+	}
+	player := selection.Option.Name
+
+	// A shared award is settled as a dead heat between the named players
+	if !slices.Contains(awarded, player) {
+		selection.Outcome = settlement.WinOrLose(false)
+	} else {
+		selection.Outcome = settlement.DeadHeatFor(len(awarded), 1)
+	}
+	if len(awarded) == 1 {
+		selection.Evaluation = fmt.Sprintf("%s was named player of the match", awarded[0])
+	} else {
+		selection.Evaluation = fmt.Sprintf("Dead heat: %s shared the player of the match award, stake divided by %d",
+			strings.Join(awarded, ", "), len(awarded))
+	}
+	return selection
+}
+
+// labelTeamPlayers names the priced options of a team player market. Bet365
+// lists each player in a "PC<id>" row carrying the name and team ("1" or "2")
+// and prices it in a row with the bare id, so priced options are returned
+// with that name and team header. Unpriced players are dropped.
+func labelTeamPlayers(market cricket.Market) cricket.Market {
+	players := make(map[string]cricket.Odd)
+	for _, odd := range market.Odds {
+		if id, ok := strings.CutPrefix(odd.ID, "PC"); ok {
+			players[id] = odd
+		}
+	}
+
+	labelled := market
+	labelled.Odds = []cricket.Odd{}
+	for _, odd := range market.Odds {
+		player, ok := players[odd.ID]
+		if !ok {
+			continue
+		}
+		odd.Name = player.Name
+		odd.Header = player.Header
+		labelled.Odds = append(labelled.Odds, odd)
+	}
+	return labelled
+}
+
+// rankLeaders returns the players sharing the highest value, sorted by name
+func rankLeaders(values map[string]int) ([]string, int) {
+	leaders, best := []string{}, -1
+	for player, value := range values {
+		if value > best {
+			leaders, best = []string{player}, value
+		} else if value == best {
+			leaders = append(leaders, player)
+		}
+	}
+	sort.Strings(leaders)
+	return leaders, best
+}
@@ -0,0 +1,101 @@
+package cricket_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// rankingMatch is a small match in which A1 and A2 tie as the top batter of
+// the match and of H, B1 is the only batter of A and nobody took a wicket
+func rankingMatch(playerOfTheMatch ...string) cricket.DetailedMatchInfo {
+	return cricket.DetailedMatchInfo{
+		HomeTeam: "H",
+		AwayTeam: "A",
+		Scorecard: &cricket.Scorecard{
+			Home:             cricket.TeamSheet{Name: "H", Players: []string{"A1", "A2", "A3"}},
+			Away:             cricket.TeamSheet{Name: "A", Players: []string{"B1", "B2"}},
+			PlayerOfTheMatch: playerOfTheMatch,
+			Innings:          []cricket.Innings{{Number: 1, BattingTeam: "H"}, {Number: 2, BattingTeam: "A"}},
+		},
+		BattingStats: map[string]cricket.BattingStats{
+			"A1": {Team: "H", Runs: 40},
+			"A2": {Team: "H", Runs: 40},
+			"A3": {Team: "H", Runs: 10},
+			"B1": {Team: "A", Runs: 30},
+		},
+		BowlingStats: map[string]cricket.BowlingStats{},
+	}
+}
+
+func TestRankingMarkets(t *testing.T) {
+	tests := []struct {
+		name     string
+		marketID string
+		header   string
+		player   string
+		want     settlement.Outcome
+	}{
+		{"two-way tie for the match", "30245", "", "A1", settlement.DeadHeatFor(2, 1)},
+		{"other tied leader", "30245", "", "A2", settlement.DeadHeatFor(2, 1)},
+		{"beaten by the leaders", "30245", "", "B1", settlement.WinOrLose(false)},
+		{"outside the XI", "30245", "", "C1", settlement.Outcome{Status: settlement.Void}},
+		{"two-way tie for the team", "1241", "1", "A2", settlement.DeadHeatFor(2, 1)},
+		{"single leader of the team", "1241", "2", "B1", settlement.WinOrLose(true)},
+		{"leader of the other team", "1241", "2", "A1", settlement.Outcome{Status: settlement.Void}},
+		{"nobody took a wicket", "30246", "", "B2", settlement.Outcome{Status: settlement.Void}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := cricket.BetSelection{Option: cricket.Odd{Header: test.header, Name: test.player}}
+			got, err := Markets.Evaluate(test.marketID, selection, rankingMatch())
+			if err != nil {
+				t.Fatalf("Evaluate(%s): %v", test.marketID, err)
+			}
+			if got.Outcome != test.want {
+				t.Errorf("%s settled %s (%s), want %s", test.player, got.Outcome, got.Evaluation, test.want)
+			}
+		})
+	}
+}
+
+func TestPlayerOfTheMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		awarded []string
+		player  string
+		want    settlement.Outcome
+	}{
+		{"sole winner", []string{"A1"}, "A1", settlement.WinOrLose(true)},
+		{"not named", []string{"A1"}, "B1", settlement.WinOrLose(false)},
+		{"shared award", []string{"A1", "B1"}, "B1", settlement.DeadHeatFor(2, 1)},
+		{"not among the shared winners", []string{"A1", "B1"}, "A2", settlement.WinOrLose(false)},
+		{"no award", nil, "A1", settlement.Outcome{Status: settlement.Void}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := cricket.BetSelection{Option: cricket.Odd{Name: test.player}}
+			got, err := Markets.Evaluate("346", selection, rankingMatch(test.awarded...))
+			if err != nil {
+				t.Fatalf("Evaluate(346): %v", err)
+			}
+			if got.Outcome != test.want {
+				t.Errorf("%s settled %s (%s), want %s", test.player, got.Outcome, got.Evaluation, test.want)
+			}
+		})
+	}
+}
+
+func TestRankingWithoutScorecard(t *testing.T) {
+	selection := cricket.BetSelection{Option: cricket.Odd{Name: "A1"}}
+	got, err := Markets.Evaluate("30245", selection, cricket.DetailedMatchInfo{})
+	if err != nil {
+		t.Fatalf("Evaluate(30245): %v", err)
+	}
+	if got.Outcome.Status != settlement.Void {
+		t.Errorf("settled %s without a scorecard, want %s", got.Outcome, settlement.Void)
+	}
+}
@@ -0,0 +1,98 @@
+package cricket_helper
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/cricket"
+)
+
+// inningsScore matches one innings of a score: runs, then optional wickets, a
+// declaration and overs, e.g. "218/5 (18.3)", "210/8d", "117-9 (20 ov)" or "350"
+var inningsScore = regexp.MustCompile(`^(\d+)(?:\s*[/-]\s*(\d+))?\s*(d|dec)?\s*(?:\(\s*(\d+(?:\.\d)?)\s*(?:ov|overs)?\s*\))?$`)
+
+// ParseMatchScore parses the ss field into the innings of each team, home side
+// first. It reads limited-overs scores such as "117-217" and
+// "117/9 (20) - 218/5 (18.3)", and multi-day ones whose innings are joined by
+// "&", such as "350 & 210/8d - 289 & 275/6".
+func ParseMatchScore(ss string) (cricket.MatchScore, error) {
+	// The sides are split on a spaced dash when there is one, as a bare dash
+	// may also separate runs from wickets
+	separator := " - "
+	if !strings.Contains(ss, separator) {
+		separator = "-"
+	}
+	sides := strings.Split(strings.TrimSpace(ss), separator)
+	if len(sides) != 2 {
+		return cricket.MatchScore{}, fmt.Errorf("invalid score format: %s", ss)
+	}
+
+	home, err := parseTeamScore(sides[0])
+	if err != nil {
+		return cricket.MatchScore{}, fmt.Errorf("invalid home score: %v", err)
+	}
+	away, err := parseTeamScore(sides[1])
+	if err != nil {
+		return cricket.MatchScore{}, fmt.Errorf("invalid away score: %v", err)
+	}
+	return cricket.MatchScore{Home: home, Away: away}, nil
+}
+
+// parseTeamScore parses a team's innings joined by "&", e.g. "350 & 210/8d"
+func parseTeamScore(text string) (cricket.TeamScore, error) {
+	team := cricket.TeamScore{}
+	for _, part := range strings.Split(text, "&") {
+		match := inningsScore.FindStringSubmatch(strings.ToLower(strings.TrimSpace(part)))
+		if match == nil {
+			return cricket.TeamScore{}, fmt.Errorf("%q is not an innings score", strings.TrimSpace(part))
+		}
+
+		innings := cricket.InningsScore{Wickets: -1, Declared: match[3] != "", Overs: match[4]}
+		innings.Runs, _ = strconv.Atoi(match[1])
+		if match[2] != "" {
+			innings.Wickets, _ = strconv.Atoi(match[2])
+			if innings.Wickets > 10 {
+				return cricket.TeamScore{}, fmt.Errorf("%q has more than 10 wickets", strings.TrimSpace(part))
+			}
+		}
+		if whole, balls, ok := strings.Cut(innings.Overs, "."); ok && balls > "5" {
+			return cricket.TeamScore{}, fmt.Errorf("%q has %s.%s overs, more than 5 balls into an over",
+				strings.TrimSpace(part), whole, balls)
+		}
+		team.Innings = append(team.Innings, innings)
+	}
+	return team, nil
+}
+
+// scorecardScore takes the score of each team from the main innings of the
+// scorecard, so it holds the wickets and overs a bare ss leaves out
+func scorecardScore(scorecard cricket.Scorecard, homeTeam string) cricket.MatchScore {
+	score := cricket.MatchScore{}
+	for _, innings := range scorecard.Innings {
+		if innings.SuperOver {
+			continue
+		}
+		entry := cricket.InningsScore{
+			Runs:     innings.Runs,
+			Wickets:  innings.Wickets,
+			Overs:    innings.Overs,
+			Declared: innings.Declared,
+		}
+		if innings.BattingTeam == homeTeam {
+			score.Home.Innings = append(score.Home.Innings, entry)
+		} else {
+			score.Away.Innings = append(score.Away.Innings, entry)
+		}
+	}
+	return score
+}
+
+// checkScore logs teams whose runs in the ss field differ from the scorecard
+func checkScore(id string, ss, scorecard cricket.MatchScore) {
+	if ss.Home.Runs() != scorecard.Home.Runs() || ss.Away.Runs() != scorecard.Away.Runs() {
+		log.Printf("Score of event %s is %s, but the scorecard adds up to %s", id, ss, scorecard)
+	}
+}
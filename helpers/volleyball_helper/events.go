@@ -0,0 +1,235 @@
+package volleyball_helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// Race targets and lead checkpoints the result feed records in every set
+var (
+	raceTargets     = []int{5, 10, 15, 20}
+	leadCheckpoints = []int{10, 20, 30}
+)
+
+func init() {
+	for set := 1; set <= maxSetMarkets; set++ {
+		for _, points := range raceTargets {
+			Markets.Register(raceToPointsMarket{set, points})
+		}
+		for _, points := range leadCheckpoints {
+			Markets.Register(leadAfterPointsMarket{set, points})
+		}
+	}
+}
+
+// Event texts of the result feed
+var (
+	raceEvent    = regexp.MustCompile(`^Set (\d+) - Race to (\d+) points - (.+)$`)
+	leadEvent    = regexp.MustCompile(`^Set (\d+) Lead After (\d+) Points - (.+)$`)
+	tieEvent     = regexp.MustCompile(`^Set (\d+) Tie After (\d+)$`)
+	setWonEvent  = regexp.MustCompile(`^Set (\d+) to (.+) - (\d+)-(\d+)$`)
+	timeoutEvent = regexp.MustCompile(`^Time ?Out$`)
+)
+
+// ParseEvents turns the event texts of a result into a typed timeline. Teams
+// are matched by name; timeouts, which carry no set, are placed in the set in
+// progress, i.e. the set after the last one won.
+func ParseEvents(result *volleyball.MatchResult) []volleyball.MatchEvent {
+	events := []volleyball.MatchEvent{}
+	currentSet := 1
+	for _, info := range result.Events {
+		text := strings.TrimSpace(info.Text)
+		event := volleyball.MatchEvent{ID: info.ID, Kind: volleyball.EventOther, Set: currentSet, Text: text}
+
+		if match := raceEvent.FindStringSubmatch(text); match != nil {
+			event.Kind = volleyball.EventRace
+			event.Set, _ = strconv.Atoi(match[1])
+			event.Points, _ = strconv.Atoi(match[2])
+			event.TeamName = match[3]
+		} else if match := leadEvent.FindStringSubmatch(text); match != nil {
+			event.Kind = volleyball.EventLead
+			event.Set, _ = strconv.Atoi(match[1])
+			event.Points, _ = strconv.Atoi(match[2])
+			event.TeamName = match[3]
+		} else if match := tieEvent.FindStringSubmatch(text); match != nil {
+			event.Kind = volleyball.EventTie
+			event.Set, _ = strconv.Atoi(match[1])
+			event.Points, _ = strconv.Atoi(match[2])
+		} else if match := setWonEvent.FindStringSubmatch(text); match != nil {
+			event.Kind = volleyball.EventSetWon
+			event.Set, _ = strconv.Atoi(match[1])
+			event.TeamName = match[2]
+			event.HomePoints, _ = strconv.Atoi(match[3])
+			event.AwayPoints, _ = strconv.Atoi(match[4])
+			currentSet = event.Set + 1
+		} else if timeoutEvent.MatchString(text) {
+			event.Kind = volleyball.EventTimeout
+		}
+
+		event.Team = teamSide(event.TeamName, result)
+		events = append(events, event)
+	}
+	return events
+}
+
+// teamSide returns "1" or "2" for a home or away team name, or "" if it is neither
+func teamSide(name string, result *volleyball.MatchResult) string {
+	switch {
+	case name == "":
+		return ""
+	case strings.EqualFold(name, result.Home.Name):
+		return "1"
+	case strings.EqualFold(name, result.Away.Name):
+		return "2"
+	}
+	return ""
+}
+
+// timelineText describes the events of one set, e.g. "Race to 5: Team A, ..."
+func timelineText(events []volleyball.MatchEvent, set int) string {
+	parts := []string{}
+	timeouts := 0
+	for _, event := range events {
+		if event.Set != set {
+			continue
+		}
+		switch event.Kind {
+		case volleyball.EventRace:
+			parts = append(parts, fmt.Sprintf("Race to %d: %s", event.Points, event.TeamName))
+		case volleyball.EventLead:
+			parts = append(parts, fmt.Sprintf("Lead after %d: %s", event.Points, event.TeamName))
+		case volleyball.EventTie:
+			parts = append(parts, fmt.Sprintf("Tied after %d", event.Points))
+		case volleyball.EventSetWon:
+			parts = append(parts, fmt.Sprintf("Won by %s %d-%d", event.TeamName, event.HomePoints, event.AwayPoints))
+		case volleyball.EventTimeout:
+			timeouts++
+		}
+	}
+	if timeouts > 0 {
+		parts = append(parts, fmt.Sprintf("%d timeouts", timeouts))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// pointsSelections creates the selections of a race or lead market. Rows
+// are picked by their team header, or by name for the "Tie" option of lead
+// markets.
+func pointsSelections(marketName string, marketID string, markets []otherMarket) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	for _, market := range markets {
+		for _, odds := range market.Odds {
+			oddsValue, ok := parseOdds(odds.Odds)
+			if !ok {
+				continue
+			}
+			option := odds.Header
+			if option == "" {
+				option = odds.Name
+			}
+			selections = append(selections, volleyball.BetSelection{
+				Market:      marketName,
+				MarketID:    marketID,
+				Selection:   option,
+				SelectionID: odds.ID,
+				Odds:        oddsValue,
+				Closed:      market.Closed,
+			})
+		}
+	}
+	return selections
+}
+
+// optionName names a team option: the team for "1" and "2", the option itself otherwise
+func optionName(option string, result volleyball.MatchResult) string {
+	if option == "1" || option == "2" {
+		return getTeamName(option, result.Home.Name, result.Away.Name)
+	}
+	return option
+}
+
+// raceToPointsMarket settles "Set N Race to X Points": the team that reaches
+// X points first in the set
+type raceToPointsMarket struct {
+	set    int
+	points int
+}
+
+func (m raceToPointsMarket) MarketID() string {
+	return setMarketKey("set_%d_race_to_%d_points", m.set, m.points)
+}
+
+func (m raceToPointsMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	return pointsSelections(fmt.Sprintf("Set %d Race to %d Points", m.set, m.points), m.MarketID(),
+		setMarkets(prematch, m.set, func(markets volleyball.SetMarkets) volleyball.MarketData { return markets.RaceTo[m.points] }))
+}
+
+func (m raceToPointsMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	set, ok := playedSet(&evaluation, ctx.Stats, m.set)
+	if !ok {
+		return settleEvaluation(evaluation)
+	}
+
+	event, ok := ctx.Stats.Event(volleyball.EventRace, m.set, m.points)
+	if !ok || event.Team == "" {
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Void}
+		evaluation.Explanation = fmt.Sprintf("No race to %d points was recorded in set %d (%d-%d)",
+			m.points, m.set, set.HomePoints, set.AwayPoints)
+		return settleEvaluation(evaluation)
+	}
+
+	evaluation.Outcome = settlement.WinOrLose(selection.Selection == event.Team)
+	evaluation.Explanation = fmt.Sprintf("%s reached %d points first in set %d. User bet: %s. Result: %s",
+		event.TeamName, m.points, m.set, optionName(selection.Selection, ctx.Result), evaluation.Outcome)
+	return settleEvaluation(evaluation)
+}
+
+// leadAfterPointsMarket settles "Set N Lead After X Points": the team ahead
+// once X points have been played in the set, or a tie
+type leadAfterPointsMarket struct {
+	set    int
+	points int
+}
+
+func (m leadAfterPointsMarket) MarketID() string {
+	return setMarketKey("set_%d_lead_after_%d_points", m.set, m.points)
+}
+
+func (m leadAfterPointsMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	return pointsSelections(fmt.Sprintf("Set %d Lead After %d Points", m.set, m.points), m.MarketID(),
+		setMarkets(prematch, m.set, func(markets volleyball.SetMarkets) volleyball.MarketData { return markets.LeadAfter[m.points] }))
+}
+
+func (m leadAfterPointsMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	set, ok := playedSet(&evaluation, ctx.Stats, m.set)
+	if !ok {
+		return settleEvaluation(evaluation)
+	}
+
+	if _, tied := ctx.Stats.Event(volleyball.EventTie, m.set, m.points); tied {
+		evaluation.Outcome = settlement.WinOrLose(strings.EqualFold(selection.Selection, "Tie"))
+		evaluation.Explanation = fmt.Sprintf("Set %d was tied after %d points. User bet: %s. Result: %s",
+			m.set, m.points, optionName(selection.Selection, ctx.Result), evaluation.Outcome)
+		return settleEvaluation(evaluation)
+	}
+
+	event, ok := ctx.Stats.Event(volleyball.EventLead, m.set, m.points)
+	if !ok || event.Team == "" {
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Void}
+		evaluation.Explanation = fmt.Sprintf("No lead after %d points was recorded in set %d (%d-%d)",
+			m.points, m.set, set.HomePoints, set.AwayPoints)
+		return settleEvaluation(evaluation)
+	}
+
+	evaluation.Outcome = settlement.WinOrLose(selection.Selection == event.Team)
+	evaluation.Explanation = fmt.Sprintf("%s led set %d after %d points. User bet: %s. Result: %s",
+		event.TeamName, m.set, m.points, optionName(selection.Selection, ctx.Result), evaluation.Outcome)
+	return settleEvaluation(evaluation)
+}
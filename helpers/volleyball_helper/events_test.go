@@ -0,0 +1,92 @@
+package volleyball_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func TestParseEvents(t *testing.T) {
+	result := &volleyball.MatchResult{
+		Home: volleyball.TeamInfo{Name: "Sporting CP Women"},
+		Away: volleyball.TeamInfo{Name: "FC Porto Women"},
+	}
+	tests := []struct {
+		text     string
+		kind     volleyball.EventKind
+		set      int
+		points   int
+		team     string
+		teamName string
+	}{
+		{"Set 1 - Race to 5 points - Sporting CP Women", volleyball.EventRace, 1, 5, "1", "Sporting CP Women"},
+		{"Time Out", volleyball.EventTimeout, 1, 0, "", ""},
+		{"Set 1 Lead After 10 Points - FC Porto Women", volleyball.EventLead, 1, 10, "2", "FC Porto Women"},
+		{"Set 1 to Sporting CP Women - 25-23", volleyball.EventSetWon, 1, 0, "1", "Sporting CP Women"},
+		{"TimeOut", volleyball.EventTimeout, 2, 0, "", ""},
+		{"Set 2 Tie After 30", volleyball.EventTie, 2, 30, "", ""},
+		{"  Set 2 - Race to 20 points - fc porto women  ", volleyball.EventRace, 2, 20, "2", "fc porto women"},
+		{"Set 2 Lead After 20 Points - Benfica Women", volleyball.EventLead, 2, 20, "", "Benfica Women"},
+		{"Set 2 to FC Porto Women - 20-25", volleyball.EventSetWon, 2, 0, "2", "FC Porto Women"},
+		{"Time Out", volleyball.EventTimeout, 3, 0, "", ""},
+		{"Set 3 - Substitution", volleyball.EventOther, 3, 0, "", ""},
+		{"Set 3 Race to 5 points", volleyball.EventOther, 3, 0, "", ""},
+	}
+	for _, test := range tests {
+		result.Events = append(result.Events, volleyball.EventInfo{Text: test.text})
+	}
+
+	events := ParseEvents(result)
+	if len(events) != len(tests) {
+		t.Fatalf("parsed %d events, want %d", len(events), len(tests))
+	}
+	for i, test := range tests {
+		got := events[i]
+		if got.Kind != test.kind || got.Set != test.set || got.Points != test.points ||
+			got.Team != test.team || got.TeamName != test.teamName {
+			t.Errorf("%q = %s set %d, %d points, team %q (%q), want %s set %d, %d points, team %q (%q)",
+				test.text, got.Kind, got.Set, got.Points, got.Team, got.TeamName,
+				test.kind, test.set, test.points, test.team, test.teamName)
+		}
+	}
+
+	if won := events[3]; won.HomePoints != 25 || won.AwayPoints != 23 {
+		t.Errorf("set 1 score = %d-%d, want 25-23", won.HomePoints, won.AwayPoints)
+	}
+}
+
+// The captured result records Sporting CP Women reaching 10 points first in
+// set 1, a tie after 30 points in set 2, FC Porto Women ahead after 10 points
+// in set 4 and no race to 20 in the 15-point fifth set.
+func TestSyntheticPointsMarkets(t *testing.T) {
+	outcomes := syntheticOutcomes(t)
+
+	tests := []struct {
+		name string
+		id   string
+		want settlement.Status
+	}{
+		{"set 1 race to 10 home", "670136461", settlement.Win},
+		{"set 1 race to 10 away", "670136462", settlement.Lose},
+		{"set 2 lead after 30 home", "670136471", settlement.Lose},
+		{"set 2 lead after 30 tie", "670136473", settlement.Win},
+		{"set 2 lead after 30 away", "670136472", settlement.Lose},
+		{"set 4 lead after 10 home", "670136485", settlement.Lose},
+		{"set 4 lead after 10 tie", "670136487", settlement.Lose},
+		{"set 4 lead after 10 away", "670136486", settlement.Win},
+		{"set 5 race to 20 home", "670136481", settlement.Void},
+		{"set 5 race to 20 away", "670136482", settlement.Void},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := outcomes[test.id]
+			if !ok {
+				t.Fatalf("selection %s was not offered", test.id)
+			}
+			if got != test.want {
+				t.Errorf("selection %s settled %s, want %s", test.id, got, test.want)
+			}
+		})
+	}
+}
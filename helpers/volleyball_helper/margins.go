@@ -0,0 +1,110 @@
+package volleyball_helper
+
+import (
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/pricing"
+)
+
+// bookKey groups the mutually exclusive selections of a market: both sides
+// of a handicap or total line share the line without its side or sign
+func bookKey(selection volleyball.BetSelection) string {
+	line := strings.TrimSpace(selection.Handicap)
+	line = strings.TrimPrefix(strings.TrimPrefix(line, "O "), "U ")
+	line = strings.TrimLeft(line, "+-")
+	return selection.Market + " " + line
+}
+
+// books splits the offered selections into books, in offer order. A selection
+// offered by several snapshots keeps its latest price.
+func books(selections []volleyball.BetSelection) ([]string, map[string][]volleyball.BetSelection) {
+	keys := []string{}
+	grouped := make(map[string][]volleyball.BetSelection)
+	for _, selection := range selections {
+		key := bookKey(selection)
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		replaced := false
+		for i, other := range grouped[key] {
+			if other.SelectionID == selection.SelectionID {
+				grouped[key][i], replaced = selection, true
+			}
+		}
+		if !replaced {
+			grouped[key] = append(grouped[key], selection)
+		}
+	}
+	return keys, grouped
+}
+
+// PriceFairOdds fills the fair odds of every offered selection with the
+// default de-vigging method. Selections whose book cannot be de-vigged keep
+// no fair odds.
+func PriceFairOdds(selections []volleyball.BetSelection) []volleyball.BetSelection {
+	fair := make(map[string]float64)
+	keys, grouped := books(selections)
+	for _, key := range keys {
+		odds := []float64{}
+		for _, selection := range grouped[key] {
+			odds = append(odds, selection.Odds)
+		}
+		prices, err := pricing.DevigOdds(odds, pricing.DefaultMethod)
+		if err != nil {
+			continue
+		}
+		for i, selection := range grouped[key] {
+			fair[selection.SelectionID] = prices[i]
+		}
+	}
+
+	for i := range selections {
+		if odds, ok := fair[selections[i].SelectionID]; ok {
+			selections[i].FairOdds = odds
+		}
+	}
+	return selections
+}
+
+// MarketMargins reports the overround of every book offered by an event, with
+// the fair odds of its selections under each de-vigging method
+func MarketMargins(prematch *volleyball.PrematchResult, result *volleyball.MatchResult) []pricing.Margin {
+	margins := []pricing.Margin{}
+	keys, grouped := books(Markets.Process(prematch))
+	for _, key := range keys {
+		book := pricing.Book{Market: strings.TrimSpace(key)}
+		for _, selection := range grouped[key] {
+			book.Add(selectionLabel(selection, result), selection.Odds)
+		}
+		margins = append(margins, pricing.NewMargin(book))
+	}
+	return margins
+}
+
+// LadderQuotes lists every price offered by an event, for checking against
+// the fractional ladder
+func LadderQuotes(prematch *volleyball.PrematchResult, result *volleyball.MatchResult) []odds.Quote {
+	quotes := []odds.Quote{}
+	keys, grouped := books(Markets.Process(prematch))
+	for _, key := range keys {
+		for _, selection := range grouped[key] {
+			quotes = append(quotes, odds.Quote{Market: strings.TrimSpace(key), Selection: selectionLabel(selection, result), Decimal: selection.Odds})
+		}
+	}
+	return quotes
+}
+
+// selectionLabel names a selection with its team and line, e.g. "FC Porto Women +1.5"
+func selectionLabel(selection volleyball.BetSelection, result *volleyball.MatchResult) string {
+	team, rest, _ := strings.Cut(selection.Selection, " ")
+	label := optionName(team, *result)
+	if rest != "" {
+		label += " " + rest
+	}
+	if selection.Handicap != "" && selection.Handicap != selection.Selection {
+		label += " " + selection.Handicap
+	}
+	return label
+}
@@ -0,0 +1,397 @@
+package volleyball_helper
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/registry"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// Markets holds the volleyball market processors keyed by Bet365 market ID
+var Markets = registry.New[*volleyball.PrematchResult, volleyball.EvaluationContext, volleyball.BetSelection, volleyball.EvaluationResult]()
+
+func init() {
+	Markets.Register(gameLinesMarket{})
+	Markets.Register(correctSetScoreMarket{})
+	for set := 1; set <= maxSetMarkets; set++ {
+		Markets.Register(setLinesMarket{set})
+	}
+	Markets.Register(matchTotalOddEvenMarket{})
+	for set := 1; set <= maxSetMarkets; set++ {
+		Markets.Register(setExtraPointsMarket{set})
+	}
+	for set := 1; set <= maxSetMarkets; set++ {
+		Markets.Register(setTotalOddEvenMarket{set})
+	}
+}
+
+// parseOdds returns the decimal odds of an entry, or false for parent rows and bad values
+func parseOdds(odds string) (float64, bool) {
+	oddsValue, err := strconv.ParseFloat(odds, 64)
+	if err != nil || oddsValue <= 0 {
+		return 0, false
+	}
+	return oddsValue, true
+}
+
+// parseTotalLine splits an "O 177.5"/"U 177.5" line into its direction and value
+func parseTotalLine(line string) (bool, float64) {
+	isOver := strings.HasPrefix(line, "O ")
+	value, _ := strconv.ParseFloat(strings.TrimSpace(line[min(2, len(line)):]), 64)
+	return isOver, value
+}
+
+// otherMarket is a market snapshot taken from the prematch "others" array
+type otherMarket struct {
+	volleyball.MarketData
+	Closed bool
+}
+
+// otherMarkets collects a market from every entry of the prematch "others" array.
+// A snapshot counts as closed when it says so itself or when the newer main
+// snapshot of the same market is closed.
+func otherMarkets(prematch *volleyball.PrematchResult, market func(sp volleyball.SpData) volleyball.MarketData) []otherMarket {
+	markets := []otherMarket{}
+	for _, other := range prematch.Others {
+		if data := market(other.Sp); len(data.Odds) > 0 {
+			closed := data.IsClosed() || closedInMain(prematch, data.ID, other.UpdatedAt)
+			markets = append(markets, otherMarket{MarketData: data, Closed: closed})
+		}
+	}
+	return markets
+}
+
+// closedInMain reports whether a main snapshot newer than updatedAt closes the market
+func closedInMain(prematch *volleyball.PrematchResult, marketID string, updatedAt string) bool {
+	mainUpdated, _ := strconv.ParseInt(prematch.Main.UpdatedAt, 10, 64)
+	otherUpdated, _ := strconv.ParseInt(updatedAt, 10, 64)
+	if mainUpdated <= otherUpdated {
+		return false
+	}
+	for _, market := range prematch.Main.Sp.Markets() {
+		if market.ID == marketID && market.IsClosed() {
+			return true
+		}
+	}
+	return false
+}
+
+// gameLinesMarket settles the winner, handicap and total lines of "Game Lines"
+type gameLinesMarket struct{}
+
+func (gameLinesMarket) MarketID() string { return "910000" }
+
+func (gameLinesMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	gameLines := prematch.Main.Sp.GameLines
+
+	// 1. Match Winner (1X2 equivalent in volleyball)
+	for _, odds := range gameLines.Odds {
+		if odds.Header != "" && odds.Handicap == "" {
+			if oddsValue, ok := parseOdds(odds.Odds); ok {
+				selections = append(selections, volleyball.BetSelection{
+					Market:      "Match Winner",
+					MarketID:    gameLines.ID,
+					Selection:   odds.Header,
+					SelectionID: odds.ID,
+					Odds:        oddsValue,
+					Closed:      gameLines.IsClosed(),
+				})
+			}
+		}
+	}
+
+	// 2. Handicap, in sets or points depending on the row group
+	groups := rowGroups(gameLines)
+	for _, odds := range gameLines.Odds {
+		if odds.Header != "" && odds.Handicap != "" && (strings.Contains(odds.Handicap, "-") || strings.Contains(odds.Handicap, "+")) {
+			if oddsValue, ok := parseOdds(odds.Odds); ok {
+				selections = append(selections, volleyball.BetSelection{
+					Market:       "Handicap",
+					MarketID:     gameLines.ID,
+					Selection:    odds.Header,
+					SelectionID:  odds.ID,
+					Odds:         oddsValue,
+					Handicap:     odds.Handicap,
+					HandicapUnit: handicapUnit(groups[odds.ID], odds.Handicap),
+					Closed:       gameLines.IsClosed(),
+				})
+			}
+		}
+	}
+
+	// 3. Total Points
+	for _, odds := range gameLines.Odds {
+		if strings.HasPrefix(odds.Handicap, "O ") || strings.HasPrefix(odds.Handicap, "U ") {
+			if oddsValue, ok := parseOdds(odds.Odds); ok {
+				selections = append(selections, volleyball.BetSelection{
+					Market:      "Total Points",
+					MarketID:    gameLines.ID,
+					Selection:   odds.Handicap,
+					SelectionID: odds.ID,
+					Odds:        oddsValue,
+					Handicap:    odds.Handicap,
+					Closed:      gameLines.IsClosed(),
+				})
+			}
+		}
+	}
+
+	return selections
+}
+
+func (gameLinesMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	result, matchStats := ctx.Result, ctx.Stats
+
+	switch selection.Market {
+	case "Match Winner":
+		evaluation.Outcome = settlement.WinOrLose(selection.Selection == matchStats.MatchWinner)
+		evaluation.Explanation = fmt.Sprintf("Match result: %d-%d sets. Winner: Team %s. User bet: Team %s to win.",
+			matchStats.HomeSetWins, matchStats.AwaySetWins, matchStats.MatchWinner, selection.Selection)
+
+	case "Handicap":
+		handicapValue, _ := strconv.ParseFloat(selection.Handicap, 64)
+
+		// Apply handicap to the set or points margin of the selected team
+		margin := matchStats.HomeSetWins - matchStats.AwaySetWins
+		if selection.HandicapUnit == volleyball.HandicapPoints {
+			margin = matchStats.HomeMatchPoints - matchStats.AwayMatchPoints
+		}
+		if selection.Selection == "2" {
+			margin = -margin
+		}
+		evaluation.Outcome = settleHandicap(handicapValue, margin)
+
+		teamName := getTeamName(selection.Selection, result.Home.Name, result.Away.Name)
+		evaluation.Explanation = fmt.Sprintf("Match result: %d-%d sets, %d-%d points. %s %s margin: %d. Applied handicap %s %s%s: adjusted margin %.2f. Result: %s",
+			matchStats.HomeSetWins, matchStats.AwaySetWins, matchStats.HomeMatchPoints, matchStats.AwayMatchPoints,
+			teamName, selection.HandicapUnit, margin, selection.Handicap, selection.HandicapUnit, splitText(handicapValue),
+			float64(margin)+handicapValue, evaluation.Outcome)
+
+	case "Total Points":
+		evaluation.Outcome = settleTotal(selection.Handicap, matchStats.TotalMatchPoints)
+		_, totalValue := parseTotalLine(selection.Handicap)
+
+		evaluation.Explanation = fmt.Sprintf("Total match points: %d. User bet: %s (threshold: %.1f). Result: %s",
+			matchStats.TotalMatchPoints, selection.Selection, totalValue, evaluation.Outcome)
+	}
+
+	return settleEvaluation(evaluation)
+}
+
+// correctSetScoreMarket settles the "Correct Set Score" market
+type correctSetScoreMarket struct{}
+
+func (correctSetScoreMarket) MarketID() string { return "910201" }
+
+func (correctSetScoreMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	market := prematch.Main.Sp.CorrectSetScore
+
+	for _, odds := range market.Odds {
+		if oddsValue, ok := parseOdds(odds.Odds); ok {
+			selections = append(selections, volleyball.BetSelection{
+				Market:      "Correct Set Score",
+				MarketID:    market.ID,
+				Selection:   fmt.Sprintf("%s %s", odds.Header, odds.Name),
+				SelectionID: odds.ID,
+				Odds:        oddsValue,
+				Closed:      market.IsClosed(),
+			})
+		}
+	}
+
+	return selections
+}
+
+func (correctSetScoreMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	result, matchStats := ctx.Result, ctx.Stats
+
+	// Parse team and score from selection
+	parts := strings.Split(selection.Selection, " ")
+	if len(parts) < 2 {
+		evaluation.Explanation = "Invalid selection format"
+		return settleEvaluation(evaluation)
+	}
+
+	team := parts[0]
+	scoreParts := strings.Split(parts[1], "-")
+	if len(scoreParts) < 2 {
+		evaluation.Explanation = "Invalid score format"
+		return settleEvaluation(evaluation)
+	}
+
+	winSets, _ := strconv.Atoi(scoreParts[0])
+	loseSets, _ := strconv.Atoi(scoreParts[1])
+
+	// Check if the prediction matches the actual result
+	if team == matchStats.MatchWinner &&
+		winSets == max(matchStats.HomeSetWins, matchStats.AwaySetWins) &&
+		loseSets == min(matchStats.HomeSetWins, matchStats.AwaySetWins) {
+		evaluation.Outcome = settlement.WinOrLose(true)
+	}
+
+	evaluation.Explanation = fmt.Sprintf("Match result: %d-%d. User bet: %s (%s) to win %s. Actual winner: %s (%s) with score %d-%d. Result: %s",
+		matchStats.HomeSetWins, matchStats.AwaySetWins, getTeamType(team), getTeamName(team, result.Home.Name, result.Away.Name), parts[1],
+		getTeamType(matchStats.MatchWinner), getTeamName(matchStats.MatchWinner, result.Home.Name, result.Away.Name),
+		max(matchStats.HomeSetWins, matchStats.AwaySetWins), min(matchStats.HomeSetWins, matchStats.AwaySetWins),
+		evaluation.Outcome)
+
+	return settleEvaluation(evaluation)
+}
+
+// matchTotalOddEvenMarket settles the "Match Total Odd/Even" market
+type matchTotalOddEvenMarket struct{}
+
+func (matchTotalOddEvenMarket) MarketID() string { return "910217" }
+
+func (m matchTotalOddEvenMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	return nameSelections("Match Total Odd/Even", m.MarketID(),
+		otherMarkets(prematch, func(sp volleyball.SpData) volleyball.MarketData { return sp.MatchTotalOddEven }))
+}
+
+func (matchTotalOddEvenMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	matchStats := ctx.Stats
+
+	isOdd := matchStats.TotalMatchPoints%2 == 1
+	evaluation.Outcome = settlement.WinOrLose((selection.Selection == "Odd" && isOdd) || (selection.Selection == "Even" && !isOdd))
+
+	evaluation.Explanation = fmt.Sprintf("Total match points: %d (%s). User bet: %s. Result: %s",
+		matchStats.TotalMatchPoints, getOddEvenText(matchStats.TotalMatchPoints), selection.Selection, evaluation.Outcome)
+
+	return settleEvaluation(evaluation)
+}
+
+// nameSelections creates one selection per odds entry, labelled by the entry name
+func nameSelections(marketName string, marketID string, markets []otherMarket) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	for _, market := range markets {
+		for _, odds := range market.Odds {
+			if oddsValue, ok := parseOdds(odds.Odds); ok {
+				selections = append(selections, volleyball.BetSelection{
+					Market:      marketName,
+					MarketID:    marketID,
+					Selection:   odds.Name,
+					SelectionID: odds.ID,
+					Odds:        oddsValue,
+					Closed:      market.Closed,
+				})
+			}
+		}
+	}
+	return selections
+}
+
+// newEvaluation starts an evaluation with the implied and fair probabilities of the selection
+func newEvaluation(selection volleyball.BetSelection) volleyball.EvaluationResult {
+	evaluation := volleyball.EvaluationResult{
+		BetSelection:       selection,
+		Outcome:            settlement.WinOrLose(false),
+		ImpliedProbability: 1.0 / selection.Odds * 100, // Calculate implied probability
+	}
+	if selection.FairOdds > 0 {
+		evaluation.FairProbability = 1.0 / selection.FairOdds * 100
+	}
+	return evaluation
+}
+
+// playedSet returns the statistics of set n, voiding the evaluation when the set was not played
+func playedSet(evaluation *volleyball.EvaluationResult, matchStats *volleyball.MatchStatistics, n int) (volleyball.SetStatistics, bool) {
+	set, ok := matchStats.Set(n)
+	if !ok {
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Void}
+		evaluation.Explanation = fmt.Sprintf("Set %d was not played", n)
+	}
+	return set, ok
+}
+
+// settleTotal settles an "O 177.5"/"U 177.5" line; a total equal to a whole-number line is a push
+// and a quarter line splits the stake across its two neighbouring lines
+func settleTotal(line string, total int) settlement.Outcome {
+	isOver, totalValue := parseTotalLine(line)
+	return settlement.SplitLine(totalValue, func(line float64) float64 {
+		if isOver {
+			return float64(total) - line
+		}
+		return line - float64(total)
+	})
+}
+
+// settleHandicap settles a handicap on the margin of the selected team; a
+// quarter line splits the stake across its two neighbouring lines
+func settleHandicap(handicap float64, margin int) settlement.Outcome {
+	return settlement.SplitLine(handicap, func(line float64) float64 { return float64(margin) + line })
+}
+
+// splitText describes how a quarter line splits the stake, or is empty for other lines
+func splitText(line float64) string {
+	lines := settlement.Split(line)
+	if len(lines) == 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (stake split across %+g and %+g)", lines[0], lines[1])
+}
+
+// maxSetHandicap is the widest set margin of a best of five match; wider
+// handicaps can only be quoted in points
+const maxSetHandicap = 3
+
+// rowGroups maps every priced row of a market to the name of its group. Bet365
+// heads each group with a "PC<id>" row, e.g. "Handicap" or "Point Handicap",
+// whose id is that of the group's first priced row; rows follow in the same
+// column order.
+func rowGroups(market volleyball.MarketData) map[string]string {
+	groups := make(map[string]string)
+	names := []string{}
+	for _, odds := range market.Odds {
+		if id, ok := strings.CutPrefix(odds.ID, "PC"); ok {
+			groups[id] = odds.Name
+			names = append(names, odds.Name)
+		}
+	}
+
+	// Rows of each team follow the group order, so the n-th priced row of a
+	// team belongs to the n-th group
+	column := make(map[string]int)
+	for _, odds := range market.Odds {
+		if strings.HasPrefix(odds.ID, "PC") || odds.Header == "" {
+			continue
+		}
+		if _, ok := groups[odds.ID]; !ok && column[odds.Header] < len(names) {
+			groups[odds.ID] = names[column[odds.Header]]
+		}
+		column[odds.Header]++
+	}
+	return groups
+}
+
+// handicapUnit tells whether a game line handicap is quoted in sets or points,
+// from the name of its row group or, failing that, the size of the line
+func handicapUnit(group string, handicap string) volleyball.HandicapUnit {
+	group = strings.ToLower(group)
+	switch {
+	case strings.Contains(group, "point"):
+		return volleyball.HandicapPoints
+	case strings.Contains(group, "set"):
+		return volleyball.HandicapSets
+	}
+	if value, err := strconv.ParseFloat(handicap, 64); err == nil && math.Abs(value) > maxSetHandicap {
+		return volleyball.HandicapPoints
+	}
+	return volleyball.HandicapSets
+}
+
+// settleEvaluation calculates profit/loss and return amount
+func settleEvaluation(evaluation volleyball.EvaluationResult) volleyball.EvaluationResult {
+	selection := evaluation.BetSelection
+	evaluation.ReturnAmount = evaluation.Outcome.Return(selection.StakeAmount, selection.Price)
+	evaluation.ProfitLoss = evaluation.ReturnAmount.Sub(selection.StakeAmount)
+	return evaluation
+}
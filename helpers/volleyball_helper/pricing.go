@@ -0,0 +1,183 @@
+package volleyball_helper
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/pricing"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// FairPrice is the margin-free price of a selection derived from the correct
+// set score odds, with the price the feed offers for it, if any
+type FairPrice struct {
+	Market      string
+	Selection   string
+	SelectionID string  // Empty when the feed does not offer the selection
+	Fair        float64 // 0 when the selection cannot win
+	Offered     float64 // 0 when the feed does not offer the selection
+
+	settle func(score pricing.SetScore) settlement.Outcome // Settles the selection on the final set score
+}
+
+// Edge is the expected profit per unit staked at the offered price
+func (p FairPrice) Edge() float64 {
+	return pricing.Edge(p.Offered, p.Fair)
+}
+
+// Settle settles the selection on the final set score of the match. A match
+// without a winner voids it.
+func (p FairPrice) Settle(stats *volleyball.MatchStatistics) settlement.Outcome {
+	if p.settle == nil || stats.MatchWinner == "" {
+		return settlement.Outcome{Status: settlement.Void}
+	}
+	return p.settle(pricing.SetScore{Home: stats.HomeSetWins, Away: stats.AwaySetWins})
+}
+
+// IsValue reports whether the offered price beats the fair price
+func (p FairPrice) IsValue() bool {
+	return p.Offered > 0 && p.Fair > 0 && p.Edge() > 0
+}
+
+// SetScoreDistribution turns the correct set score odds of an event into a
+// margin-free distribution. Rows are headed by the winning team and named
+// with the winner's sets first, e.g. header "2" and name "3-1".
+func SetScoreDistribution(prematch *volleyball.PrematchResult) pricing.SetScoreDistribution {
+	quotes := []pricing.SetScoreQuote{}
+	for _, odds := range prematch.Main.Sp.CorrectSetScore.Odds {
+		oddsValue, ok := parseOdds(odds.Odds)
+		if !ok {
+			continue
+		}
+		winnerSets, loserSets, ok := parseSetScore(odds.Name)
+		if !ok {
+			continue
+		}
+		switch odds.Header {
+		case "1":
+			quotes = append(quotes, pricing.SetScoreQuote{Home: winnerSets, Away: loserSets, Odds: oddsValue})
+		case "2":
+			quotes = append(quotes, pricing.SetScoreQuote{Home: loserSets, Away: winnerSets, Odds: oddsValue})
+		}
+	}
+	return pricing.NewSetScoreDistribution(quotes)
+}
+
+// parseSetScore parses a "3-1" set score with the winner's sets first
+func parseSetScore(score string) (int, int, bool) {
+	parts := strings.Split(strings.TrimSpace(score), "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	winnerSets, winnerErr := strconv.Atoi(parts[0])
+	loserSets, loserErr := strconv.Atoi(parts[1])
+	return winnerSets, loserSets, winnerErr == nil && loserErr == nil && winnerSets > loserSets
+}
+
+// roundOdds rounds a price to 2 decimal places, the precision of the feed
+func roundOdds(odds float64) float64 {
+	return math.Round(odds*100) / 100
+}
+
+// PriceMarkets derives fair prices for the match winner, set handicaps of
+// ±1.5 and ±2.5, total sets over/under 3.5 and 4.5, each team to win a set and
+// the match going to a deciding set from the correct set score odds, and pairs
+// them with the game lines prices. Every price settles on the final set score;
+// the markets the feed does not offer are only reported here and cannot be
+// bet on.
+func PriceMarkets(prematch *volleyball.PrematchResult) []FairPrice {
+	distribution := SetScoreDistribution(prematch)
+	if len(distribution) == 0 {
+		return nil
+	}
+
+	// Offered game lines keyed by market and selection
+	offered := make(map[string]volleyball.BetSelection)
+	for _, selection := range (gameLinesMarket{}).Process(prematch) {
+		switch {
+		case selection.Market == "Match Winner":
+			offered["Match Winner "+selection.Selection] = selection
+		case selection.Market == "Handicap" && selection.HandicapUnit == volleyball.HandicapSets:
+			offered["Set Handicap "+selection.Selection+" "+selection.Handicap] = selection
+		}
+	}
+
+	prices := []FairPrice{}
+	add := func(key, market, selection string, fair float64, settle func(score pricing.SetScore) settlement.Outcome) {
+		price := FairPrice{Market: market, Selection: selection, Fair: fair, settle: settle}
+		if quoted, ok := offered[key]; ok {
+			price.SelectionID = quoted.SelectionID
+			price.Offered = quoted.Odds
+		}
+		prices = append(prices, price)
+	}
+
+	for _, team := range []string{"1", "2"} {
+		add("Match Winner "+team, "Match Winner", team, pricing.FairOdds(distribution.Winner(team), 0),
+			func(score pricing.SetScore) settlement.Outcome { return settlement.WinOrLose(score.Winner() == team) })
+	}
+	for _, line := range []float64{-2.5, -1.5, 1.5, 2.5} {
+		for _, team := range []string{"1", "2"} {
+			handicap := fmt.Sprintf("%+.1f", line)
+			add("Set Handicap "+team+" "+handicap, "Set Handicap", team+" "+handicap,
+				pricing.FairOdds(distribution.SetHandicap(team, line)),
+				func(score pricing.SetScore) settlement.Outcome { return settleHandicap(line, score.Margin(team)) })
+		}
+	}
+	for _, line := range []float64{3.5, 4.5} {
+		for _, over := range []bool{true, false} {
+			side := "U"
+			if over {
+				side = "O"
+			}
+			total := fmt.Sprintf("%s %.1f", side, line)
+			add("", "Total Sets", total, pricing.FairOdds(distribution.TotalSets(over, line)),
+				func(score pricing.SetScore) settlement.Outcome { return settleTotal(total, score.Home+score.Away) })
+		}
+	}
+	for _, team := range []string{"1", "2"} {
+		add("", "To Win a Set", team, pricing.FairOdds(distribution.WinASet(team), 0),
+			func(score pricing.SetScore) settlement.Outcome { return settlement.WinOrLose(score.Sets(team) > 0) })
+	}
+	deciding := distribution.DecidingSet()
+	reachedDecider := func(score pricing.SetScore) bool { return score.Home-score.Away == 1 || score.Away-score.Home == 1 }
+	add("", "Deciding Set", "Yes", pricing.FairOdds(deciding, 0),
+		func(score pricing.SetScore) settlement.Outcome { return settlement.WinOrLose(reachedDecider(score)) })
+	add("", "Deciding Set", "No", pricing.FairOdds(1-deciding, 0),
+		func(score pricing.SetScore) settlement.Outcome { return settlement.WinOrLose(!reachedDecider(score)) })
+	return prices
+}
+
+// PrintFairPrices prints the fair prices of an event next to the offered odds,
+// flagging offered prices that beat the fair price as value, with the result
+// of each selection on the final set score
+func PrintFairPrices(prices []FairPrice, result *volleyball.MatchResult, stats *volleyball.MatchStatistics) {
+	if len(prices) == 0 {
+		return
+	}
+
+	fmt.Println("\n================== FAIR PRICES (CORRECT SET SCORE) ==================")
+	fmt.Printf("%-15s %-30s %8s %8s %-14s %s\n", "Market", "Selection", "Fair", "Offered", "Edge", "Result")
+	for _, price := range prices {
+		selection := price.Selection
+		if team, rest, _ := strings.Cut(selection, " "); team == "1" || team == "2" {
+			selection = strings.TrimSpace(getTeamName(team, result.Home.Name, result.Away.Name) + " " + rest)
+		}
+
+		fair, offered, edge := "-", "-", ""
+		if price.Fair > 0 {
+			fair = fmt.Sprintf("%.2f", roundOdds(price.Fair))
+		}
+		if price.Offered > 0 && price.Fair > 0 {
+			offered = fmt.Sprintf("%.2f", price.Offered)
+			edge = fmt.Sprintf("%+.1f%%", price.Edge()*100)
+			if price.IsValue() {
+				edge += " VALUE"
+			}
+		}
+		fmt.Printf("%-15s %-30s %8s %8s %-14s %s\n", price.Market, selection, fair, offered, edge, price.Settle(stats))
+	}
+}
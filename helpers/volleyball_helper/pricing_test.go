@@ -0,0 +1,68 @@
+package volleyball_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func TestSettleFairPrices(t *testing.T) {
+	prematchData, err := LoadVolleyballPrematchData("../../data/volleyball_prematch.json")
+	if err != nil {
+		t.Fatalf("loading prematch data: %v", err)
+	}
+	prices := PriceMarkets(&prematchData.Results[0])
+	if len(prices) == 0 {
+		t.Fatal("no fair prices derived from the correct set score")
+	}
+
+	// finalScore is the statistics of a finished match won home-away in sets
+	finalScore := func(home, away int) *volleyball.MatchStatistics {
+		winner := "1"
+		if away > home {
+			winner = "2"
+		}
+		return &volleyball.MatchStatistics{HomeSetWins: home, AwaySetWins: away, MatchWinner: winner}
+	}
+
+	tests := []struct {
+		name      string
+		stats     *volleyball.MatchStatistics
+		market    string
+		selection string
+		want      settlement.Status
+	}{
+		{"home wins a set in a 2-3 defeat", finalScore(2, 3), "To Win a Set", "1", settlement.Win},
+		{"away wins a set in a 1-3 win", finalScore(1, 3), "To Win a Set", "2", settlement.Win},
+		{"swept team wins no set", finalScore(3, 0), "To Win a Set", "2", settlement.Lose},
+		{"winner of a sweep wins a set", finalScore(3, 0), "To Win a Set", "1", settlement.Win},
+		{"3-2 goes to the deciding set", finalScore(2, 3), "Deciding Set", "Yes", settlement.Win},
+		{"no deciding set loses at 3-2", finalScore(3, 2), "Deciding Set", "No", settlement.Lose},
+		{"3-1 ends before the deciding set", finalScore(3, 1), "Deciding Set", "Yes", settlement.Lose},
+		{"sweep ends before the deciding set", finalScore(0, 3), "Deciding Set", "No", settlement.Win},
+		{"match winner", finalScore(1, 3), "Match Winner", "2", settlement.Win},
+		{"set handicap covered", finalScore(3, 2), "Set Handicap", "2 +1.5", settlement.Win},
+		{"set handicap not covered", finalScore(3, 1), "Set Handicap", "1 -2.5", settlement.Lose},
+		{"total sets over", finalScore(3, 1), "Total Sets", "O 3.5", settlement.Win},
+		{"total sets under", finalScore(3, 1), "Total Sets", "U 3.5", settlement.Lose},
+		{"unfinished match", &volleyball.MatchStatistics{HomeSetWins: 1, AwaySetWins: 1}, "To Win a Set", "1", settlement.Void},
+		{"unfinished match deciding set", &volleyball.MatchStatistics{HomeSetWins: 2, AwaySetWins: 2}, "Deciding Set", "Yes", settlement.Void},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, price := range prices {
+				if price.Market != test.market || price.Selection != test.selection {
+					continue
+				}
+				if got := price.Settle(test.stats); got.Status != test.want {
+					t.Errorf("%s %s on %d-%d settled %s, want %s", test.market, test.selection,
+						test.stats.HomeSetWins, test.stats.AwaySetWins, got, test.want)
+				}
+				return
+			}
+			t.Fatalf("no fair price for %s %s", test.market, test.selection)
+		})
+	}
+}
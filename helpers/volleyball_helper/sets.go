@@ -0,0 +1,178 @@
+package volleyball_helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// maxSetMarkets is the highest set number set markets are registered for
+const maxSetMarkets = 5
+
+// setMarketKey returns the feed key a set market is registered under, e.g.
+// "set_2_lines" for "Set 2 Lines" or "set_1_race_to_10_points". The captured
+// feed only prices set 1, whose IDs do not tell how later sets are numbered,
+// so set markets are keyed by the sp key the feed files them under rather
+// than by ID.
+func setMarketKey(format string, set int, args ...any) string {
+	return fmt.Sprintf(format, append([]any{set}, args...)...)
+}
+
+// setMarkets collects a market of set n from the prematch "others" array
+func setMarkets(prematch *volleyball.PrematchResult, set int, market func(markets volleyball.SetMarkets) volleyball.MarketData) []otherMarket {
+	return otherMarkets(prematch, func(sp volleyball.SpData) volleyball.MarketData { return market(sp.Set(set)) })
+}
+
+// setLinesMarket settles the winner, handicap and total lines of "Set N Lines"
+type setLinesMarket struct {
+	set int
+}
+
+func (m setLinesMarket) MarketID() string { return setMarketKey("set_%d_lines", m.set) }
+
+func (m setLinesMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	markets := setMarkets(prematch, m.set, func(markets volleyball.SetMarkets) volleyball.MarketData { return markets.Lines })
+
+	for _, market := range markets {
+		for _, odds := range market.Odds {
+			oddsValue, ok := parseOdds(odds.Odds)
+			if !ok {
+				continue
+			}
+			selection := volleyball.BetSelection{
+				MarketID:    m.MarketID(),
+				Selection:   odds.Header,
+				SelectionID: odds.ID,
+				Odds:        oddsValue,
+				Closed:      market.Closed,
+			}
+
+			switch {
+			case odds.Name == "Winner" && odds.Header != "" && odds.Handicap == "":
+				selection.Market = fmt.Sprintf("Set %d Winner", m.set)
+			case odds.Name == "Handicap" && odds.Header != "" && strings.ContainsAny(odds.Handicap, "+-"):
+				selection.Market = fmt.Sprintf("Set %d Handicap", m.set)
+				selection.Handicap = odds.Handicap
+				selection.HandicapUnit = volleyball.HandicapPoints
+			case strings.HasPrefix(odds.Handicap, "O ") || strings.HasPrefix(odds.Handicap, "U "):
+				selection.Market = fmt.Sprintf("Set %d Total Points", m.set)
+				selection.Selection = odds.Handicap
+				selection.Handicap = odds.Handicap
+			default:
+				continue
+			}
+			selections = append(selections, selection)
+		}
+	}
+
+	return selections
+}
+
+func (m setLinesMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	result := ctx.Result
+	set, ok := playedSet(&evaluation, ctx.Stats, m.set)
+	if !ok {
+		return settleEvaluation(evaluation)
+	}
+
+	teamName := getTeamName(selection.Selection, result.Home.Name, result.Away.Name)
+	switch strings.TrimPrefix(selection.Market, fmt.Sprintf("Set %d ", m.set)) {
+	case "Winner":
+		evaluation.Outcome = settlement.WinOrLose(selection.Selection == set.Winner)
+		actualWinner := getTeamName(set.Winner, result.Home.Name, result.Away.Name)
+
+		evaluation.Explanation = fmt.Sprintf("Set %d result: %d-%d. Winner: %s. User bet: %s to win Set %d. Result: %s",
+			m.set, set.HomePoints, set.AwayPoints, actualWinner, teamName, m.set, evaluation.Outcome)
+
+	case "Handicap":
+		handicapValue, _ := strconv.ParseFloat(selection.Handicap, 64)
+
+		// Apply handicap to the point difference of the set
+		pointDiff := set.HomePoints - set.AwayPoints
+		if selection.Selection == "2" {
+			pointDiff = -pointDiff
+		}
+		evaluation.Outcome = settleHandicap(handicapValue, pointDiff)
+
+		evaluation.Explanation = fmt.Sprintf("Set %d result: %d-%d. %s point difference: %d. Applied handicap %s points%s: adjusted difference %.2f. Result: %s",
+			m.set, set.HomePoints, set.AwayPoints, teamName, pointDiff, selection.Handicap, splitText(handicapValue),
+			float64(pointDiff)+handicapValue, evaluation.Outcome)
+
+	case "Total Points":
+		evaluation.Outcome = settleTotal(selection.Handicap, set.TotalPoints)
+		_, totalValue := parseTotalLine(selection.Handicap)
+
+		evaluation.Explanation = fmt.Sprintf("Set %d total points: %d. User bet: %s (threshold: %.1f). Result: %s",
+			m.set, set.TotalPoints, selection.Selection, totalValue, evaluation.Outcome)
+	}
+
+	return settleEvaluation(evaluation)
+}
+
+// setExtraPointsMarket settles the "Set N To Go To Extra Points" market
+type setExtraPointsMarket struct {
+	set int
+}
+
+func (m setExtraPointsMarket) MarketID() string {
+	return setMarketKey("set_%d_to_go_to_extra_points", m.set)
+}
+
+func (m setExtraPointsMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	return nameSelections(fmt.Sprintf("Set %d Extra Points", m.set), m.MarketID(),
+		setMarkets(prematch, m.set, func(markets volleyball.SetMarkets) volleyball.MarketData { return markets.ExtraPoints }))
+}
+
+func (m setExtraPointsMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	set, ok := playedSet(&evaluation, ctx.Stats, m.set)
+	if !ok {
+		return settleEvaluation(evaluation)
+	}
+
+	evaluation.Outcome = settlement.WinOrLose((selection.Selection == "Yes" && set.ExtraPoints) ||
+		(selection.Selection == "No" && !set.ExtraPoints))
+
+	extraPointsText := "No"
+	if set.ExtraPoints {
+		extraPointsText = "Yes"
+	}
+
+	evaluation.Explanation = fmt.Sprintf("Set %d had extra points: %s (%d-%d, played to %d). User bet: %s. Result: %s",
+		m.set, extraPointsText, set.HomePoints, set.AwayPoints, set.TargetPoints, selection.Selection, evaluation.Outcome)
+
+	return settleEvaluation(evaluation)
+}
+
+// setTotalOddEvenMarket settles the "Set N Total Odd/Even" market
+type setTotalOddEvenMarket struct {
+	set int
+}
+
+func (m setTotalOddEvenMarket) MarketID() string { return setMarketKey("set_%d_total_odd_even", m.set) }
+
+func (m setTotalOddEvenMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	return nameSelections(fmt.Sprintf("Set %d Total Odd/Even", m.set), m.MarketID(),
+		setMarkets(prematch, m.set, func(markets volleyball.SetMarkets) volleyball.MarketData { return markets.TotalOddEven }))
+}
+
+func (m setTotalOddEvenMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	set, ok := playedSet(&evaluation, ctx.Stats, m.set)
+	if !ok {
+		return settleEvaluation(evaluation)
+	}
+
+	isOdd := set.TotalPoints%2 == 1
+	evaluation.Outcome = settlement.WinOrLose((selection.Selection == "Odd" && isOdd) || (selection.Selection == "Even" && !isOdd))
+
+	evaluation.Explanation = fmt.Sprintf("Set %d total points: %d (%s). User bet: %s. Result: %s",
+		m.set, set.TotalPoints, getOddEvenText(set.TotalPoints), selection.Selection, evaluation.Outcome)
+
+	return settleEvaluation(evaluation)
+}
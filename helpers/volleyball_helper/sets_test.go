@@ -0,0 +1,76 @@
+package volleyball_helper
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+// The synthetic fixture adds set markets and a point handicap that the
+// captured feed in data/ does not price, settled against the captured result:
+// sets 25-23, 20-25, 25-20, 9-25 and 9-15.
+func TestSyntheticSetMarkets(t *testing.T) {
+	outcomes := syntheticOutcomes(t)
+
+	tests := []struct {
+		name string
+		id   string
+		want settlement.Status
+	}{
+		{"set 2 home winner", "670136401", settlement.Lose},
+		{"set 2 away winner", "670136402", settlement.Win},
+		{"set 2 home +4.75", "670136403", settlement.HalfLose},
+		{"set 2 away -4.75", "670136404", settlement.HalfWin},
+		{"set 2 over 44.5", "670136405", settlement.Win},
+		{"set 2 under 44.5", "670136406", settlement.Lose},
+		{"set 3 odd", "670136411", settlement.Win},
+		{"set 3 even", "670136410", settlement.Lose},
+		{"set 4 home +2.5", "670136423", settlement.Lose},
+		{"set 4 away -2.5", "670136424", settlement.Win},
+		{"set 4 under 45.5", "670136426", settlement.Win},
+		{"set 5 away winner", "670136432", settlement.Win},
+		{"set 5 over 27.5", "670136433", settlement.Lose},
+		{"set 5 extra points yes", "670136441", settlement.Lose},
+		{"set 5 extra points no", "670136440", settlement.Win},
+		{"match home -4.5 points", "670136450", settlement.Lose},
+		{"match away +4.5 points", "670136451", settlement.Win},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := outcomes[test.id]
+			if !ok {
+				t.Fatalf("selection %s was not offered", test.id)
+			}
+			if got != test.want {
+				t.Errorf("selection %s settled %s, want %s", test.id, got, test.want)
+			}
+		})
+	}
+}
+
+// syntheticOutcomes settles every selection of the synthetic prematch fixture
+// against the captured result, keyed by selection ID
+func syntheticOutcomes(t *testing.T) map[string]settlement.Status {
+	t.Helper()
+	prematchData, err := LoadVolleyballPrematchData("testdata/prematch_synthetic.json")
+	if err != nil {
+		t.Fatalf("loading prematch fixture: %v", err)
+	}
+	resultData, err := LoadVolleyballResultData("../../data/volleyball_result.json")
+	if err != nil {
+		t.Fatalf("loading result data: %v", err)
+	}
+	events, _ := PairEvents(prematchData, resultData)
+	if len(events) != 1 {
+		t.Fatalf("paired %d events, want 1", len(events))
+	}
+	event := events[0]
+
+	selections := CreateBetSelections(&event.Prematch)
+	evaluations := EvaluateBetSelections(selections, &event.Result, CalculateMatchStatistics(&event.Result))
+	outcomes := map[string]settlement.Status{}
+	for _, evaluation := range evaluations {
+		outcomes[evaluation.BetSelection.SelectionID] = evaluation.Outcome.Status
+	}
+	return outcomes
+}
@@ -0,0 +1,167 @@
+package volleyball_helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func init() {
+	Markets.Register(teamStatMarket{
+		id:     "team_with_most_points_won_on_serve",
+		name:   "Team With Most Points Won On Serve",
+		stat:   "points won on serve",
+		market: func(sp volleyball.SpData) volleyball.MarketData { return sp.MostPointsOnServe },
+		value:  func(stats *volleyball.MatchStatistics) *volleyball.TeamStatistic { return stats.PointsWonOnServe },
+	})
+	Markets.Register(teamStatMarket{
+		id:     "team_with_longest_point_streak",
+		name:   "Team With Longest Point Streak",
+		stat:   "longest point streak",
+		market: func(sp volleyball.SpData) volleyball.MarketData { return sp.TeamLongestStreak },
+		value:  func(stats *volleyball.MatchStatistics) *volleyball.TeamStatistic { return stats.LongestStreak },
+	})
+	Markets.Register(longestStreakMarket{})
+}
+
+// parseTeamStatistic parses a ["home", "away"] statistic of the result feed,
+// or returns nil when it is missing or malformed
+func parseTeamStatistic(values []string) *volleyball.TeamStatistic {
+	if len(values) != 2 {
+		return nil
+	}
+	home, homeErr := strconv.Atoi(strings.TrimSpace(values[0]))
+	away, awayErr := strconv.Atoi(strings.TrimSpace(values[1]))
+	if homeErr != nil || awayErr != nil {
+		return nil
+	}
+	return &volleyball.TeamStatistic{Home: home, Away: away}
+}
+
+// teamStatMarket settles a market won by the team with the higher value of a
+// match statistic. A tie wins the "Tie" option when one is priced and loses
+// the team selections; otherwise team selections are pushed.
+type teamStatMarket struct {
+	id     string
+	name   string
+	stat   string // Statistic used in the evaluation, e.g. "points won on serve"
+	market func(sp volleyball.SpData) volleyball.MarketData
+	value  func(stats *volleyball.MatchStatistics) *volleyball.TeamStatistic
+}
+
+func (m teamStatMarket) MarketID() string { return m.id }
+
+func (m teamStatMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	for _, market := range otherMarkets(prematch, m.market) {
+		tieOffered := false
+		for _, odds := range market.Odds {
+			if strings.EqualFold(odds.Name, "Tie") {
+				tieOffered = true
+			}
+		}
+
+		for _, odds := range market.Odds {
+			oddsValue, ok := parseOdds(odds.Odds)
+			if !ok {
+				continue
+			}
+			option := odds.Header
+			if option == "" {
+				option = odds.Name
+			}
+			selections = append(selections, volleyball.BetSelection{
+				Market:      m.name,
+				MarketID:    m.id,
+				Selection:   option,
+				SelectionID: odds.ID,
+				Odds:        oddsValue,
+				TieOffered:  tieOffered,
+				Closed:      market.Closed,
+			})
+		}
+	}
+	return selections
+}
+
+func (m teamStatMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	stat := m.value(ctx.Stats)
+	if stat == nil {
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Void}
+		evaluation.Explanation = fmt.Sprintf("The result does not report each team's %s", m.stat)
+		return settleEvaluation(evaluation)
+	}
+
+	result := ctx.Result
+	leader := stat.Leader()
+	option := optionName(selection.Selection, result)
+	switch {
+	case leader != "":
+		evaluation.Outcome = settlement.WinOrLose(selection.Selection == leader)
+		evaluation.Explanation = fmt.Sprintf("Each team's %s: %s %d, %s %d. User bet: %s. Result: %s",
+			m.stat, result.Home.Name, stat.Home, result.Away.Name, stat.Away, option, evaluation.Outcome)
+	case strings.EqualFold(selection.Selection, "Tie"):
+		evaluation.Outcome = settlement.WinOrLose(true)
+		evaluation.Explanation = fmt.Sprintf("Both teams had %d %s. User bet: Tie. Result: %s", stat.Home, m.stat, evaluation.Outcome)
+	case selection.TieOffered:
+		evaluation.Outcome = settlement.WinOrLose(false)
+		evaluation.Explanation = fmt.Sprintf("Both teams had %d %s and the tie was priced. User bet: %s. Result: %s",
+			stat.Home, m.stat, option, evaluation.Outcome)
+	default:
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Push}
+		evaluation.Explanation = fmt.Sprintf("Both teams had %d %s and no tie was priced, stake returned. User bet: %s",
+			stat.Home, m.stat, option)
+	}
+	return settleEvaluation(evaluation)
+}
+
+// longestStreakMarket settles the over/under lines of the longest run of
+// consecutive points by either team
+type longestStreakMarket struct{}
+
+func (longestStreakMarket) MarketID() string { return "longest_point_streak" }
+
+func (m longestStreakMarket) Process(prematch *volleyball.PrematchResult) []volleyball.BetSelection {
+	selections := []volleyball.BetSelection{}
+	markets := otherMarkets(prematch, func(sp volleyball.SpData) volleyball.MarketData { return sp.LongestStreak })
+	for _, market := range markets {
+		for _, odds := range market.Odds {
+			if !strings.HasPrefix(odds.Handicap, "O ") && !strings.HasPrefix(odds.Handicap, "U ") {
+				continue
+			}
+			if oddsValue, ok := parseOdds(odds.Odds); ok {
+				selections = append(selections, volleyball.BetSelection{
+					Market:      "Longest Point Streak",
+					MarketID:    m.MarketID(),
+					Selection:   odds.Handicap,
+					SelectionID: odds.ID,
+					Odds:        oddsValue,
+					Handicap:    odds.Handicap,
+					Closed:      market.Closed,
+				})
+			}
+		}
+	}
+	return selections
+}
+
+func (longestStreakMarket) Evaluate(selection volleyball.BetSelection, ctx volleyball.EvaluationContext) volleyball.EvaluationResult {
+	evaluation := newEvaluation(selection)
+	streak := ctx.Stats.LongestStreak
+	if streak == nil {
+		evaluation.Outcome = settlement.Outcome{Status: settlement.Void}
+		evaluation.Explanation = "The result does not report each team's longest point streak"
+		return settleEvaluation(evaluation)
+	}
+
+	longest := max(streak.Home, streak.Away)
+	evaluation.Outcome = settleTotal(selection.Handicap, longest)
+	_, line := parseTotalLine(selection.Handicap)
+	evaluation.Explanation = fmt.Sprintf("Longest point streak: %d (%s %d, %s %d). User bet: %s (threshold: %.1f). Result: %s",
+		longest, ctx.Result.Home.Name, streak.Home, ctx.Result.Away.Name, streak.Away, selection.Selection, line, evaluation.Outcome)
+	return settleEvaluation(evaluation)
+}
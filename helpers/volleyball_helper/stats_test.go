@@ -0,0 +1,138 @@
+package volleyball_helper
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/models/volleyball"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+)
+
+func TestParseTeamStatistic(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   *volleyball.TeamStatistic
+	}{
+		{"home and away", []string{"33", "53"}, &volleyball.TeamStatistic{Home: 33, Away: 53}},
+		{"padded values", []string{" 4 ", "7"}, &volleyball.TeamStatistic{Home: 4, Away: 7}},
+		{"zero", []string{"0", "0"}, &volleyball.TeamStatistic{}},
+		{"missing", nil, nil},
+		{"one team only", []string{"33"}, nil},
+		{"three values", []string{"33", "53", "1"}, nil},
+		{"not a number", []string{"x", "53"}, nil},
+		{"empty value", []string{"33", ""}, nil},
+		{"decimal", []string{"33.5", "53"}, nil},
+	}
+
+	for _, test := range tests {
+		if got := parseTeamStatistic(test.values); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: parseTeamStatistic(%q) = %+v, want %+v", test.name, test.values, got, test.want)
+		}
+	}
+}
+
+// statContext is an evaluation context whose serve and streak stats are the
+// given pairs, nil when missing
+func statContext(serve, streak *volleyball.TeamStatistic) volleyball.EvaluationContext {
+	return volleyball.EvaluationContext{
+		Result: volleyball.MatchResult{
+			Home: volleyball.TeamInfo{Name: "Sporting CP Women"},
+			Away: volleyball.TeamInfo{Name: "FC Porto Women"},
+		},
+		Stats: &volleyball.MatchStatistics{PointsWonOnServe: serve, LongestStreak: streak},
+	}
+}
+
+func TestTeamStatMarkets(t *testing.T) {
+	tests := []struct {
+		name       string
+		marketID   string
+		selection  string
+		tieOffered bool
+		serve      *volleyball.TeamStatistic
+		streak     *volleyball.TeamStatistic
+		want       settlement.Status
+	}{
+		{"home leads on serve", "team_with_most_points_won_on_serve", "1", true, &volleyball.TeamStatistic{Home: 53, Away: 33}, nil, settlement.Win},
+		{"away leads on serve", "team_with_most_points_won_on_serve", "1", true, &volleyball.TeamStatistic{Home: 33, Away: 53}, nil, settlement.Lose},
+		{"tie loses when a lead is recorded", "team_with_most_points_won_on_serve", "Tie", true, &volleyball.TeamStatistic{Home: 33, Away: 53}, nil, settlement.Lose},
+		{"tie priced and won", "team_with_most_points_won_on_serve", "Tie", true, &volleyball.TeamStatistic{Home: 40, Away: 40}, nil, settlement.Win},
+		{"team loses a priced tie", "team_with_most_points_won_on_serve", "2", true, &volleyball.TeamStatistic{Home: 40, Away: 40}, nil, settlement.Lose},
+		{"team pushed without a tie price", "team_with_most_points_won_on_serve", "2", false, &volleyball.TeamStatistic{Home: 40, Away: 40}, nil, settlement.Push},
+		{"serve stat missing", "team_with_most_points_won_on_serve", "1", true, nil, &volleyball.TeamStatistic{Home: 4, Away: 7}, settlement.Void},
+		{"away has the longest streak", "team_with_longest_point_streak", "2", false, nil, &volleyball.TeamStatistic{Home: 4, Away: 7}, settlement.Win},
+		{"streaks level", "team_with_longest_point_streak", "1", false, nil, &volleyball.TeamStatistic{Home: 6, Away: 6}, settlement.Push},
+		{"streak stat missing", "team_with_longest_point_streak", "2", false, &volleyball.TeamStatistic{Home: 33, Away: 53}, nil, settlement.Void},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selection := volleyball.BetSelection{MarketID: test.marketID, Selection: test.selection, Odds: 2, TieOffered: test.tieOffered}
+			got, err := Markets.Evaluate(test.marketID, selection, statContext(test.serve, test.streak))
+			if err != nil {
+				t.Fatalf("Evaluate(%s): %v", test.marketID, err)
+			}
+			if got.Outcome.Status != test.want {
+				t.Errorf("%s settled %s (%s), want %s", test.selection, got.Outcome, got.Explanation, test.want)
+			}
+		})
+	}
+}
+
+func TestLongestStreakMarket(t *testing.T) {
+	tests := []struct {
+		line   string
+		streak *volleyball.TeamStatistic
+		want   settlement.Status
+	}{
+		{"O 6.5", &volleyball.TeamStatistic{Home: 4, Away: 7}, settlement.Win},
+		{"U 6.5", &volleyball.TeamStatistic{Home: 4, Away: 7}, settlement.Lose},
+		{"O 6.5", &volleyball.TeamStatistic{Home: 6, Away: 5}, settlement.Lose},
+		{"U 6.5", &volleyball.TeamStatistic{Home: 6, Away: 5}, settlement.Win},
+		{"O 6", &volleyball.TeamStatistic{Home: 6, Away: 5}, settlement.Push},
+		{"O 6.5", nil, settlement.Void},
+	}
+
+	for _, test := range tests {
+		selection := volleyball.BetSelection{Selection: test.line, Handicap: test.line, Odds: 1.83}
+		got, err := Markets.Evaluate("longest_point_streak", selection, statContext(nil, test.streak))
+		if err != nil {
+			t.Fatalf("Evaluate(longest_point_streak): %v", err)
+		}
+		if got.Outcome.Status != test.want {
+			t.Errorf("%s with streaks %+v settled %s, want %s", test.line, test.streak, got.Outcome, test.want)
+		}
+	}
+}
+
+// The captured result records 33-53 points won on serve and longest streaks of
+// 4 and 7, so FC Porto Women win both team markets and the streak goes over 6.5
+func TestSyntheticStatMarkets(t *testing.T) {
+	outcomes := syntheticOutcomes(t)
+
+	tests := []struct {
+		name string
+		id   string
+		want settlement.Status
+	}{
+		{"most points on serve home", "670136491", settlement.Lose},
+		{"most points on serve tie", "670136493", settlement.Lose},
+		{"most points on serve away", "670136492", settlement.Win},
+		{"longest streak over 6.5", "670136501", settlement.Win},
+		{"longest streak under 6.5", "670136502", settlement.Lose},
+		{"team with longest streak home", "670136511", settlement.Lose},
+		{"team with longest streak away", "670136512", settlement.Win},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := outcomes[test.id]
+			if !ok {
+				t.Fatalf("selection %s was not offered", test.id)
+			}
+			if got != test.want {
+				t.Errorf("selection %s settled %s, want %s", test.id, got, test.want)
+			}
+		})
+	}
+}
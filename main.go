@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/yesetoda/bet365-evaluator-go/excuter/cricket_excuter"
+	"github.com/yesetoda/bet365-evaluator-go/excuter/multiples_excuter"
 	"github.com/yesetoda/bet365-evaluator-go/excuter/volleyball_excuter"
 )
 
@@ -14,5 +15,10 @@ func main() {
 	fmt.Println("Starting Volleyball evaluation...")
 	volleyball_excuter.VolleyballExecutor()
 	fmt.Println("_________________________________________________________________________________________________________________________________")
-	fmt.Println("Volleyball evaluation completed.")	
+	fmt.Println("Volleyball evaluation completed.")
+	fmt.Println("_________________________________________________________________________________________________________________________________")
+	fmt.Println("Starting multiples evaluation...")
+	multiples_excuter.MultiplesExecutor()
+	fmt.Println("_________________________________________________________________________________________________________________________________")
+	fmt.Println("Multiples evaluation completed.")
 }
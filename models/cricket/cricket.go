@@ -1,5 +1,11 @@
 package cricket
 
+import (
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+	"github.com/yesetoda/bet365-evaluator-go/timestamp"
+)
 
 // Odd represents a betting odd from the JSON
 type Odd struct {
@@ -11,61 +17,99 @@ type Odd struct {
 	Handicap string `json:"handicap,omitempty"`
 }
 
+// Market represents a single prematch market and its odds
+type Market struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Odds []Odd  `json:"odds"`
+	Open *int   `json:"open,omitempty"`
+}
+
+// IsClosed reports whether the feed explicitly marks the market as closed (open: 0)
+func (m Market) IsClosed() bool {
+	return m.Open != nil && *m.Open == 0
+}
+
 // BetSelection represents a selected bet
 type BetSelection struct {
-	Market       string
-	Selection    string
-	Odds         float64
-	IsWinner     bool
-	Evaluation   string
-	ConfidenceLevel string
-	AvailableOptions []string
+	Market            string
+	MarketID          string
+	SelectionID       string
+	Selection         string
+	Odds              float64
+	FairOdds          float64   // Odds with the bookmaker margin removed; 0 when the market cannot be de-vigged
+	Price             odds.Odds // Ladder fraction the odds were derived from, used to settle returns exactly
+	Outcome           settlement.Outcome
+	Evaluation        string
+	ConfidenceLevel   string
+	AvailableOptions  []string
 	MarketDescription string
-	PotentialProfit float64
-	RiskAssessment string
-	OddsDecimal    float64
-	OddsAmerican   string
-	OddsFractional string
+	PotentialProfit   money.Money
+	RiskAssessment    string
+	OddsDecimal       float64
+	OddsAmerican      string
+	OddsFractional    string
+	Option            Odd // Feed entry the selection was created from
+	StakeAmount       money.Money
+	Closed            bool // Market was closed (open: 0) in the prematch feed
+	TieOffered        bool // A Draw/Tie option was priced, so team selections lose on a tie
 }
 
 // DetailedMatchInfo contains enriched match information
 type DetailedMatchInfo struct {
-	HomeTeam      string
-	AwayTeam      string
-	HomeScore     int
-	AwayScore     int
-	Stadium       string
-	City          string
-	Country       string
-	Capacity      string
-	MatchDate     string
-	LeagueName    string
-	BattingStats  map[string]BattingStats
-	BowlingStats  map[string]BowlingStats
+	HomeTeam     string
+	AwayTeam     string
+	HomeScore    int
+	AwayScore    int
+	Score        MatchScore // Innings of each team; HomeScore and AwayScore are their runs
+	Stadium      string
+	City         string
+	Country      string
+	Capacity     string
+	MatchDate    string
+	Kickoff      timestamp.Timestamp // Scheduled start; zero when the feed left it out
+	InPlay       timestamp.Timestamp // In-play betting opened
+	Confirmed    timestamp.Timestamp // Result confirmed
+	LeagueName   string
+	BattingStats map[string]BattingStats
+	BowlingStats map[string]BowlingStats
+	Scorecard    *Scorecard       // nil when no scorecard was supplied for the event
+	Deliveries   *MatchDeliveries // nil when no ball-by-ball data was supplied
+	Partnerships [][]Partnership  // Partnerships of each innings, derived from the deliveries
+	Firsts       MatchFirsts
+	Outcome      MatchOutcome // Official result, which decides how markets settle
 }
 
-// BattingStats represents key batting statistics for demonstration
+// BattingStats represents a batter's figures across the match
 type BattingStats struct {
-	Runs         int
-	Balls        int
-	StrikeRate   float64
-	Boundaries   int
-	Sixes        int
+	Team       string
+	Runs       int
+	Balls      int
+	StrikeRate float64
+	Boundaries int // Fours
+	Sixes      int
 }
 
-// BowlingStats represents key bowling statistics for demonstration
+// BowlingStats represents a bowler's figures across the match
 type BowlingStats struct {
+	Team         string
 	Overs        float64
 	RunsConceded int
 	Wickets      int
 	Economy      float64
 }
 
+// MarketInput bundles the prematch odds of an event with the match details used to label selections
+type MarketInput struct {
+	Prematch  CricketPrematchResult
+	MatchInfo DetailedMatchInfo
+}
+
 // BettingHistory represents simulated past betting performance
 type BettingHistory struct {
-	Market       string
+	Market        string
 	WinPercentage float64
-	AvgOdds      float64
-	TotalBets    int
-	ProfitLoss   float64
-}
\ No newline at end of file
+	AvgOdds       float64
+	TotalBets     int
+	ProfitLoss    money.Money
+}
@@ -0,0 +1,94 @@
+package cricket
+
+// CricketDeliveryData represents the structure of the cricket ball-by-ball JSON
+type CricketDeliveryData struct {
+	Success int               `json:"success"`
+	Results []MatchDeliveries `json:"results"`
+}
+
+// MatchDeliveries holds every delivery of a single event
+type MatchDeliveries struct {
+	ID       string              `json:"id"`
+	Bet365ID string              `json:"bet365_id"`
+	Innings  []InningsDeliveries `json:"innings"`
+}
+
+// InningsDeliveries holds the deliveries of one innings in the order they were bowled
+type InningsDeliveries struct {
+	Number      int        `json:"number"`
+	BattingTeam string     `json:"batting_team"`
+	BowlingTeam string     `json:"bowling_team"`
+	SuperOver   bool       `json:"super_over,omitempty"`
+	Deliveries  []Delivery `json:"deliveries"`
+}
+
+// Extra types of a delivery
+const (
+	ExtraWide    = "wide"
+	ExtraNoBall  = "noball"
+	ExtraBye     = "bye"
+	ExtraLegBye  = "legbye"
+	ExtraPenalty = "penalty"
+)
+
+// Delivery is a single ball. Over is 1-based and Ball counts every delivery
+// of the over, including wides and no-balls.
+type Delivery struct {
+	Over       int     `json:"over"`
+	Ball       int     `json:"ball"`
+	Batter     string  `json:"batter"`
+	NonStriker string  `json:"non_striker"`
+	Bowler     string  `json:"bowler"`
+	Runs       int     `json:"runs"`   // Runs off the bat
+	Extras     int     `json:"extras"` // Runs not credited to the batter
+	ExtraType  string  `json:"extra_type,omitempty"`
+	Wicket     *Wicket `json:"wicket,omitempty"`
+}
+
+// Wicket describes a dismissal
+type Wicket struct {
+	Kind      string `json:"kind"` // caught, bowled, lbw, run out, stumped, ...
+	PlayerOut string `json:"player_out"`
+	Fielder   string `json:"fielder,omitempty"`
+}
+
+// Total returns the runs the delivery added to the team score
+func (d Delivery) Total() int {
+	return d.Runs + d.Extras
+}
+
+// IsLegal reports whether the delivery counts towards the over
+func (d Delivery) IsLegal() bool {
+	return d.ExtraType != ExtraWide && d.ExtraType != ExtraNoBall
+}
+
+// FacedByBatter reports whether the delivery counts as a ball faced by the striker
+func (d Delivery) FacedByBatter() bool {
+	return d.ExtraType != ExtraWide
+}
+
+// CreditedToBowler reports whether the wicket counts in the bowler's figures
+func (w Wicket) CreditedToBowler() bool {
+	switch w.Kind {
+	case "run out", "retired hurt", "retired out", "obstructing the field":
+		return false
+	}
+	return true
+}
+
+// Partnership is the stand between two batters for a wicket
+type Partnership struct {
+	Wicket   int // 1 for the opening stand
+	Batters  [2]string
+	Runs     int
+	Balls    int
+	Unbroken bool
+}
+
+// MatchFirsts records the first events of the match
+type MatchFirsts struct {
+	FirstBall        *Delivery
+	FirstScoringShot *Delivery // First delivery with runs off the bat
+	FirstBoundary    *Delivery
+	FirstWicket      *Delivery
+}
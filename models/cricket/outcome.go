@@ -0,0 +1,80 @@
+package cricket
+
+import "fmt"
+
+// ResultType is how a match ended
+type ResultType string
+
+// Result types
+const (
+	ResultWin       ResultType = "win"        // Decided in normal play, by runs or wickets
+	ResultTie       ResultType = "tie"        // Scores level, with no super over to decide it
+	ResultSuperOver ResultType = "super over" // Scores level and decided by a super over
+	ResultDraw      ResultType = "draw"       // A multi-day match ran out of time before the side batting last was out or won
+	ResultNoResult  ResultType = "no result"  // Play started but too little was possible for a result
+	ResultAbandoned ResultType = "abandoned"  // Abandoned or cancelled without a ball bowled
+)
+
+// Margin units
+const (
+	MarginRuns    = "runs"    // The side batting first won
+	MarginWickets = "wickets" // The side batting second chased down its target
+	MarginInnings = "innings" // The side that batted once outscored both innings of the other, by Margin runs
+)
+
+// SuperOverResult is the outcome of the super overs that decided a tie
+type SuperOverResult struct {
+	Played int            // Super overs played by each side, counting replays of a tied super over
+	Winner string         // Team that won the last super over; empty if it was tied too
+	Runs   map[string]int // Runs of each team in the last super over
+}
+
+// MatchOutcome is the official result of a match, which decides how markets settle
+type MatchOutcome struct {
+	Type           ResultType
+	Winner         string           // Winning team; empty without a winner
+	Margin         int              // Winning margin in MarginUnit; 0 for a super over or without a winner
+	MarginUnit     string           // MarginRuns, MarginWickets or MarginInnings
+	DLS            bool             // The target was revised by the Duckworth-Lewis-Stern method
+	Target         int              // Runs the side batting second needed; 0 when it did not bat
+	SuperOver      *SuperOverResult // nil when no super over was bowled
+	ScheduledOvers int              // Overs per side before any interruption; 0 when unknown
+	ReducedOvers   bool             // The overs of an innings were cut from the scheduled overs
+}
+
+// Decided reports whether the match produced a result, tied ones included
+func (o MatchOutcome) Decided() bool {
+	return o.Type != ResultNoResult && o.Type != ResultAbandoned
+}
+
+// String describes the outcome, e.g. "Mumbai Indians won by 100 runs"
+func (o MatchOutcome) String() string {
+	switch o.Type {
+	case ResultNoResult:
+		return "No result"
+	case ResultAbandoned:
+		return "Match abandoned"
+	case ResultTie:
+		return "Match tied"
+	case ResultDraw:
+		return "Match drawn"
+	case ResultSuperOver:
+		return fmt.Sprintf("Match tied, %s won the super over", o.Winner)
+	}
+
+	unit := o.MarginUnit
+	if unit == MarginInnings {
+		unit = MarginRuns
+	}
+	if o.Margin == 1 {
+		unit = unit[:len(unit)-1]
+	}
+	text := fmt.Sprintf("%s won by %d %s", o.Winner, o.Margin, unit)
+	if o.MarginUnit == MarginInnings {
+		text = fmt.Sprintf("%s won by an innings and %d %s", o.Winner, o.Margin, unit)
+	}
+	if o.DLS {
+		text += " (DLS method)"
+	}
+	return text
+}
@@ -1,157 +1,187 @@
 package cricket
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
 
 // CricketPrematchData represents the structure of the cricket prematch JSON
 type CricketPrematchData struct {
-	Success int `json:"success"`
-	Results []struct {
-		FI       string `json:"FI"`
-		EventID  string `json:"event_id"`
-		FirstOver struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				FirstOverTotalRuns struct {
-					ID    string `json:"id"`
-					Name  string `json:"name"`
-					Odds  []Odd  `json:"odds"`
-				} `json:"1st_over_total_runs"`
-				FirstOverTotalRunsOddEven struct {
-					ID    string `json:"id"`
-					Name  string `json:"name"`
-					Odds  []Odd  `json:"odds"`
-				} `json:"1st_over_total_runs_odd_even"`
-			} `json:"sp"`
-		} `json:"1st_over"`
-		Innings1 struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				FirstInningsScore struct {
-					ID    string `json:"id"`
-					Name  string `json:"name"`
-					Odds  []Odd  `json:"odds"`
-				} `json:"1st_innings_score"`
-				FirstInningsOfMatchBowledOut struct {
-					ID    string `json:"id"`
-					Name  string `json:"name"`
-					Odds  []Odd  `json:"odds"`
-					Open  int    `json:"open"`
-				} `json:"1st_innings_of_match_bowled_out?"`
-			} `json:"sp"`
-		} `json:"innings_1"`
-		Main struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				ToWinTheMatch struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"to_win_the_match"`
-				TeamTopBatter struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"team_top_batter"`
-				TeamTopBowler struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-					Open int    `json:"open"`
-				} `json:"team_top_bowler"`
-				PlayerOfTheMatch struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-					Open int    `json:"open"`
-				} `json:"player_of_the_match"`
-				FirstWicketMethod struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-					Open int    `json:"open"`
-				} `json:"1st_wicket_method"`
-				PlayerPerformance struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"player_performance"`
-			} `json:"sp"`
-		} `json:"main"`
-		Match struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				TeamToMakeHighest1st6OversScore struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"team_to_make_highest_1st_6_overs_score"`
-				ToGoToSuperOver struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"to_go_to_super_over?"`
-				RunsAtFallOf1stWicket struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"runs_at_fall_of_1st_wicket"`
-				AFiftyToBeScored struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"a_fifty_to_be_scored"`
-				AHundredToBeScored struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"a_hundred_to_be_scored_in_the_match"`
-				MostMatchSixes struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"most_match_sixes"`
-				MostMatchFours struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"most_match_fours"`
-				HighestIndividualScore struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"highest_individual_score"`
-			} `json:"sp"`
-		} `json:"match"`
-		Player struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				BatterMatchRuns struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"batter_match_runs"`
-				BatterMilestones struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"batter_milestones"`
-				BowlerTotalMatchWickets struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-					Odds []Odd  `json:"odds"`
-				} `json:"bowler_total_match_wickets"`
-			} `json:"sp"`
-		} `json:"player"`
-		Schedule struct {
-			UpdatedAt string `json:"updated_at"`
-			Key       string `json:"key"`
-			SP        struct {
-				Main []Odd `json:"main"`
-			} `json:"sp"`
-		} `json:"schedule"`
-	} `json:"results"`
+	Success int                     `json:"success"`
+	Results []CricketPrematchResult `json:"results"`
+}
+
+// CricketPrematchResult holds the prematch markets of a single event
+type CricketPrematchResult struct {
+	FI        string `json:"FI"`
+	EventID   string `json:"event_id"`
+	FirstOver struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			FirstOverTotalRuns        Market `json:"1st_over_total_runs"`
+			FirstOverTotalRunsOddEven Market `json:"1st_over_total_runs_odd_even"`
+		} `json:"sp"`
+	} `json:"1st_over"`
+	Innings1 struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			FirstInningsScore            Market `json:"1st_innings_score"`
+			FirstInningsOfMatchBowledOut Market `json:"1st_innings_of_match_bowled_out?"`
+		} `json:"sp"`
+	} `json:"innings_1"`
+	Main struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			ToWinTheMatch     Market `json:"to_win_the_match"`
+			TeamTopBatter     Market `json:"team_top_batter"`
+			TeamTopBowler     Market `json:"team_top_bowler"`
+			PlayerOfTheMatch  Market `json:"player_of_the_match"`
+			FirstWicketMethod Market `json:"1st_wicket_method"`
+			PlayerPerformance Market `json:"player_performance"`
+		} `json:"sp"`
+	} `json:"main"`
+	Match struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			TeamToMakeHighest1st6OversScore Market `json:"team_to_make_highest_1st_6_overs_score"`
+			ToGoToSuperOver                 Market `json:"to_go_to_super_over?"`
+			RunsAtFallOf1stWicket           Market `json:"runs_at_fall_of_1st_wicket"`
+			AFiftyToBeScored                Market `json:"a_fifty_to_be_scored"`
+			AHundredToBeScored              Market `json:"a_hundred_to_be_scored_in_the_match"`
+			MostMatchSixes                  Market `json:"most_match_sixes"`
+			MostMatchFours                  Market `json:"most_match_fours"`
+			HighestIndividualScore          Market `json:"highest_individual_score"`
+		} `json:"sp"`
+	} `json:"match"`
+	Player struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			BatterMatchRuns         Market `json:"batter_match_runs"`
+			BatterMilestones        Market `json:"batter_milestones"`
+			BowlerTotalMatchWickets Market `json:"bowler_total_match_wickets"`
+		} `json:"sp"`
+	} `json:"player"`
+	Schedule struct {
+		UpdatedAt string `json:"updated_at"`
+		Key       string `json:"key"`
+		SP        struct {
+			Main []Odd `json:"main"`
+		} `json:"sp"`
+	} `json:"schedule"`
+	Others []struct {
+		UpdatedAt string            `json:"updated_at"`
+		SP        map[string]Market `json:"sp"`
+	} `json:"others"`
+
+	// Catalog lists every market of every section, including those without a
+	// typed field above, such as the fixture-named keys
+	Catalog []CatalogMarket `json:"-"`
+}
+
+// CatalogMarket is a market found anywhere in the prematch feed
+type CatalogMarket struct {
+	Section   string // Feed section, e.g. "main" or "others[3]"
+	Key       string // Market key within the section, e.g. "to_win_the_match"
+	UpdatedAt string
+	Market    Market
+}
+
+// UnmarshalJSON decodes the typed sections and collects every "sp" market,
+// whatever its key, into the catalog. Named sections come first, sorted by
+// name, followed by the others array in feed order.
+func (p *CricketPrematchResult) UnmarshalJSON(data []byte) error {
+	type typed CricketPrematchResult
+	if err := json.Unmarshal(data, (*typed)(p)); err != nil {
+		return err
+	}
+
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(sections))
+	for name := range sections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	p.Catalog = []CatalogMarket{}
+	for _, name := range names {
+		var section struct {
+			UpdatedAt string                     `json:"updated_at"`
+			SP        map[string]json.RawMessage `json:"sp"`
+		}
+		// FI, event_id and the others array are not sections
+		if err := json.Unmarshal(sections[name], &section); err != nil || section.SP == nil {
+			continue
+		}
+		for _, key := range sortedKeys(section.SP) {
+			var market Market
+			// schedule.sp.main is a list of fixtures rather than a market
+			if err := json.Unmarshal(section.SP[key], &market); err != nil {
+				continue
+			}
+			p.Catalog = append(p.Catalog, CatalogMarket{Section: name, Key: key, UpdatedAt: section.UpdatedAt, Market: market})
+		}
+	}
+
+	for i, other := range p.Others {
+		for _, key := range sortedKeys(other.SP) {
+			p.Catalog = append(p.Catalog, CatalogMarket{
+				Section:   fmt.Sprintf("others[%d]", i),
+				Key:       key,
+				UpdatedAt: other.UpdatedAt,
+				Market:    other.SP[key],
+			})
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Market returns the market stored under key in any section. The first copy
+// with odds is preferred, since Bet365 often repeats a market empty and
+// closed in one section while pricing it in another.
+func (p CricketPrematchResult) Market(key string) (Market, bool) {
+	var found *Market
+	for i, entry := range p.Catalog {
+		if entry.Key != key {
+			continue
+		}
+		if len(entry.Market.Odds) > 0 {
+			return entry.Market, true
+		}
+		if found == nil {
+			found = &p.Catalog[i].Market
+		}
+	}
+	if found == nil {
+		return Market{}, false
+	}
+	return *found, true
+}
+
+// OtherMarket returns the market stored under key in the others array
+func (p CricketPrematchResult) OtherMarket(key string) Market {
+	for _, other := range p.Others {
+		if market, ok := other.SP[key]; ok {
+			return market
+		}
+	}
+	return Market{}
 }
@@ -0,0 +1,83 @@
+package cricket
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InningsScore is a team's score in one innings, e.g. 218/5 (18.3)
+type InningsScore struct {
+	Runs     int
+	Wickets  int    // Wickets lost; -1 when the score does not give them
+	Overs    string // Overs faced, e.g. "18.3"; empty when not given
+	Declared bool
+}
+
+// AllOut reports whether the side lost all ten wickets
+func (s InningsScore) AllOut() bool {
+	return s.Wickets >= 10
+}
+
+// String prints the innings as the feed writes it, e.g. "218/5 (18.3)", "210/8d" or "117"
+// when the wickets are not known
+func (s InningsScore) String() string {
+	text := fmt.Sprint(s.Runs)
+	if s.Wickets >= 0 {
+		text += fmt.Sprintf("/%d", s.Wickets)
+	}
+	if s.Declared {
+		text += "d"
+	}
+	if s.Overs != "" {
+		text += fmt.Sprintf(" (%s)", s.Overs)
+	}
+	return text
+}
+
+// TeamScore holds a team's innings in the order they were batted
+type TeamScore struct {
+	Innings []InningsScore
+}
+
+// Runs returns the team's runs across its innings
+func (t TeamScore) Runs() int {
+	runs := 0
+	for _, innings := range t.Innings {
+		runs += innings.Runs
+	}
+	return runs
+}
+
+// Last returns the team's latest innings, and false if it has not batted
+func (t TeamScore) Last() (InningsScore, bool) {
+	if len(t.Innings) == 0 {
+		return InningsScore{}, false
+	}
+	return t.Innings[len(t.Innings)-1], true
+}
+
+// String prints the team's innings joined by "&", e.g. "350 & 210/8d"
+func (t TeamScore) String() string {
+	parts := make([]string, len(t.Innings))
+	for i, innings := range t.Innings {
+		parts[i] = innings.String()
+	}
+	return strings.Join(parts, " & ")
+}
+
+// MatchScore is the score of both teams, parsed from the ss field or taken
+// from the scorecard
+type MatchScore struct {
+	Home TeamScore
+	Away TeamScore
+}
+
+// MultiInnings reports whether a team batted more than once, as in a multi-day match
+func (s MatchScore) MultiInnings() bool {
+	return len(s.Home.Innings) > 1 || len(s.Away.Innings) > 1
+}
+
+// String prints the score home side first, e.g. "117/9 (20) - 218/5 (18.3)"
+func (s MatchScore) String() string {
+	return s.Home.String() + " - " + s.Away.String()
+}
@@ -0,0 +1,100 @@
+package cricket
+
+// CricketScorecardData represents the structure of the cricket scorecard JSON
+type CricketScorecardData struct {
+	Success int         `json:"success"`
+	Results []Scorecard `json:"results"`
+}
+
+// Scorecard holds the team sheets and innings of a single event
+type Scorecard struct {
+	ID               string    `json:"id"`
+	Bet365ID         string    `json:"bet365_id"`
+	Home             TeamSheet `json:"home"`
+	Away             TeamSheet `json:"away"`
+	PlayerOfTheMatch []string  `json:"player_of_the_match,omitempty"` // More than one name for a shared award
+	Status           string    `json:"status,omitempty"`              // "no result" or "abandoned" for a match without a result
+	ScheduledOvers   int       `json:"scheduled_overs,omitempty"`     // Overs per side before any interruption, e.g. 20
+	Innings          []Innings `json:"innings"`
+}
+
+// TeamSheet lists the playing XI of a team
+type TeamSheet struct {
+	Name    string   `json:"name"`
+	Players []string `json:"players"`
+}
+
+// Innings is one team's batting innings
+type Innings struct {
+	Number        int             `json:"number"`
+	BattingTeam   string          `json:"batting_team"`
+	BowlingTeam   string          `json:"bowling_team"`
+	Runs          int             `json:"runs"`
+	Wickets       int             `json:"wickets"`
+	Overs         string          `json:"overs"` // Overs bowled, e.g. "16.1"
+	SuperOver     bool            `json:"super_over,omitempty"`
+	Declared      bool            `json:"declared,omitempty"`
+	MaxOvers      int             `json:"max_overs,omitempty"` // Overs available when cut from the scheduled overs, e.g. by rain
+	Target        int             `json:"target,omitempty"`    // Revised target set by the DLS method
+	Batters       []BatterScore   `json:"batters"`
+	DidNotBat     []string        `json:"did_not_bat"`
+	Bowlers       []BowlerFigures `json:"bowlers"`
+	Extras        Extras          `json:"extras"`
+	FallOfWickets []FallOfWicket  `json:"fall_of_wickets"`
+	OverRuns      []int           `json:"over_runs"` // Runs conceded in each over, first over first
+}
+
+// BatterScore is a batter's line of the scorecard
+type BatterScore struct {
+	Name      string `json:"name"`
+	Dismissal string `json:"dismissal"` // "not out" when the batter was not dismissed
+	Runs      int    `json:"runs"`
+	Balls     int    `json:"balls"`
+	Fours     int    `json:"fours"`
+	Sixes     int    `json:"sixes"`
+}
+
+// BowlerFigures is a bowler's line of the scorecard
+type BowlerFigures struct {
+	Name    string `json:"name"`
+	Overs   string `json:"overs"`
+	Maidens int    `json:"maidens"`
+	Runs    int    `json:"runs"`
+	Wickets int    `json:"wickets"`
+	Wides   int    `json:"wides"`
+	NoBalls int    `json:"no_balls"`
+}
+
+// Extras are the runs not credited to a batter
+type Extras struct {
+	Byes    int `json:"byes"`
+	LegByes int `json:"leg_byes"`
+	Wides   int `json:"wides"`
+	NoBalls int `json:"no_balls"`
+	Penalty int `json:"penalty"`
+}
+
+// FallOfWicket records the team score when a wicket fell
+type FallOfWicket struct {
+	Wicket int    `json:"wicket"`
+	Runs   int    `json:"runs"`
+	Player string `json:"player"`
+	Over   string `json:"over"`
+}
+
+// Total returns the sum of all extras
+func (e Extras) Total() int {
+	return e.Byes + e.LegByes + e.Wides + e.NoBalls + e.Penalty
+}
+
+// TeamOf returns the team whose XI includes the player, or "" if neither does
+func (s Scorecard) TeamOf(player string) string {
+	for _, sheet := range []TeamSheet{s.Home, s.Away} {
+		for _, name := range sheet.Players {
+			if name == player {
+				return sheet.Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package volleyball
+
+// EventKind is the type of a match event
+type EventKind string
+
+// Event kinds parsed from the event texts of a result
+const (
+	EventRace    EventKind = "race"    // "Set 1 - Race to 5 points - Team"
+	EventLead    EventKind = "lead"    // "Set 1 Lead After 10 Points - Team"
+	EventTie     EventKind = "tie"     // "Set 2 Tie After 30"
+	EventSetWon  EventKind = "set"     // "Set 1 to Team - 25-23"
+	EventTimeout EventKind = "timeout" // "Time Out"
+	EventOther   EventKind = "other"   // Any text the parser does not know
+)
+
+// MatchEvent is a typed event of the match timeline
+type MatchEvent struct {
+	ID         string
+	Kind       EventKind
+	Set        int    // Set the event happened in; timeouts take the set in progress
+	Points     int    // Race target or lead checkpoint, e.g. 5 for "Race to 5 points"
+	Team       string // "1" or "2", empty for ties, timeouts and unknown teams
+	TeamName   string // Team name as written in the event text
+	HomePoints int    // Final score of the set for EventSetWon
+	AwayPoints int
+	Text       string
+}
+
+// Event returns the first event of a kind in set n at the given points, or
+// false if there is none
+func (m *MatchStatistics) Event(kind EventKind, set int, points int) (MatchEvent, bool) {
+	for _, event := range m.Events {
+		if event.Kind == kind && event.Set == set && event.Points == points {
+			return event, true
+		}
+	}
+	return MatchEvent{}, false
+}
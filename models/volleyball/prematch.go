@@ -1,5 +1,11 @@
 package volleyball
 
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+)
 
 // Prematch Data Structures
 type PrematchData struct {
@@ -27,19 +33,127 @@ type OtherData struct {
 }
 
 type SpData struct {
-	GameLines            MarketData `json:"game_lines"`
-	CorrectSetScore      MarketData `json:"correct_set_score"`
-	MatchTotalOddEven    MarketData `json:"match_total_odd_even"`
-	Set1Lines            MarketData `json:"set_1_lines"`
-	Set1ToGoToExtraPoints MarketData `json:"set_1_to_go_to_extra_points"`
-	Set1TotalOddEven     MarketData `json:"set_1_total_odd_even"`
+	GameLines         MarketData         `json:"game_lines"`
+	CorrectSetScore   MarketData         `json:"correct_set_score"`
+	MatchTotalOddEven MarketData         `json:"match_total_odd_even"`
+	MostPointsOnServe MarketData         `json:"team_with_most_points_won_on_serve"`
+	LongestStreak     MarketData         `json:"longest_point_streak"`
+	TeamLongestStreak MarketData         `json:"team_with_longest_point_streak"`
+	Sets              map[int]SetMarkets `json:"-"` // Set markets keyed by set number, e.g. "set_2_lines"
+}
+
+// SetMarkets holds the markets offered on a single set
+type SetMarkets struct {
+	Lines        MarketData         // set_N_lines: winner, handicap and total points
+	ExtraPoints  MarketData         // set_N_to_go_to_extra_points
+	TotalOddEven MarketData         // set_N_total_odd_even
+	RaceTo       map[int]MarketData // set_N_race_to_X_points, keyed by X
+	LeadAfter    map[int]MarketData // set_N_lead_after_X_points, keyed by X
+}
+
+// setMarketKey matches the keys of set markets, e.g. "set_3_total_odd_even"
+var setMarketKey = regexp.MustCompile(`^set_(\d+)_(lines|to_go_to_extra_points|total_odd_even|race_to_(\d+)_points|lead_after_(\d+)_points)$`)
+
+// UnmarshalJSON decodes the named markets and collects the set markets of
+// every set number present in the snapshot
+func (sp *SpData) UnmarshalJSON(data []byte) error {
+	type spAlias SpData
+	var alias spAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, value := range raw {
+		match := setMarketKey.FindStringSubmatch(key)
+		if match == nil {
+			continue
+		}
+		var market MarketData
+		if err := json.Unmarshal(value, &market); err != nil {
+			return err
+		}
+		number, _ := strconv.Atoi(match[1])
+		if alias.Sets == nil {
+			alias.Sets = make(map[int]SetMarkets)
+		}
+		set := alias.Sets[number]
+		switch match[2] {
+		case "lines":
+			set.Lines = market
+		case "to_go_to_extra_points":
+			set.ExtraPoints = market
+		case "total_odd_even":
+			set.TotalOddEven = market
+		default:
+			if match[3] != "" {
+				points, _ := strconv.Atoi(match[3])
+				set.RaceTo = addMarket(set.RaceTo, points, market)
+			} else {
+				points, _ := strconv.Atoi(match[4])
+				set.LeadAfter = addMarket(set.LeadAfter, points, market)
+			}
+		}
+		alias.Sets[number] = set
+	}
+
+	*sp = SpData(alias)
+	return nil
+}
+
+// addMarket adds a market keyed by points, creating the map when needed
+func addMarket(markets map[int]MarketData, points int, market MarketData) map[int]MarketData {
+	if markets == nil {
+		markets = make(map[int]MarketData)
+	}
+	markets[points] = market
+	return markets
+}
+
+// Set returns the markets of set n; they are empty when the snapshot has none
+func (sp SpData) Set(n int) SetMarkets {
+	return sp.Sets[n]
+}
+
+// Markets lists every market of the snapshot, set markets in set order
+func (sp SpData) Markets() []MarketData {
+	markets := []MarketData{sp.GameLines, sp.CorrectSetScore, sp.MatchTotalOddEven, sp.MostPointsOnServe, sp.LongestStreak, sp.TeamLongestStreak}
+	for _, number := range sortedKeys(sp.Sets) {
+		set := sp.Sets[number]
+		markets = append(markets, set.Lines, set.ExtraPoints, set.TotalOddEven)
+		for _, points := range sortedKeys(set.RaceTo) {
+			markets = append(markets, set.RaceTo[points])
+		}
+		for _, points := range sortedKeys(set.LeadAfter) {
+			markets = append(markets, set.LeadAfter[points])
+		}
+	}
+	return markets
+}
+
+// sortedKeys returns the keys of a map keyed by set number or points, in order
+func sortedKeys[V any](markets map[int]V) []int {
+	keys := []int{}
+	for key := range markets {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
 }
 
 type MarketData struct {
-	ID    string     `json:"id"`
-	Name  string     `json:"name"`
-	Odds  []OddsData `json:"odds"`
-	Open  int        `json:"open,omitempty"`
+	ID   string     `json:"id"`
+	Name string     `json:"name"`
+	Odds []OddsData `json:"odds"`
+	Open *int       `json:"open,omitempty"`
+}
+
+// IsClosed reports whether the feed explicitly marks the market as closed (open: 0)
+func (m MarketData) IsClosed() bool {
+	return m.Open != nil && *m.Open == 0
 }
 
 type OddsData struct {
@@ -1,4 +1,16 @@
 package volleyball
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+	"github.com/yesetoda/bet365-evaluator-go/settlement"
+	"github.com/yesetoda/bet365-evaluator-go/timestamp"
+)
+
 // Result Data Structures
 type ResultData struct {
 	Success int           `json:"success"`
@@ -6,22 +18,22 @@ type ResultData struct {
 }
 
 type MatchResult struct {
-	ID              string        `json:"id"`
-	SportID         string        `json:"sport_id"`
-	Time            string        `json:"time"`
-	TimeStatus      string        `json:"time_status"`
-	League          LeagueInfo    `json:"league"`
-	Home            TeamInfo      `json:"home"`
-	Away            TeamInfo      `json:"away"`
-	SS              string        `json:"ss"`
-	Scores          ScoresInfo    `json:"scores"`
-	Stats           StatsInfo     `json:"stats"`
-	Events          []EventInfo   `json:"events"`
-	Extra           ExtraInfo     `json:"extra"`
-	InplayCreatedAt string        `json:"inplay_created_at"`
-	InplayUpdatedAt string        `json:"inplay_updated_at"`
-	ConfirmedAt     string        `json:"confirmed_at"`
-	Bet365ID        string        `json:"bet365_id"`
+	ID              string              `json:"id"`
+	SportID         string              `json:"sport_id"`
+	Time            timestamp.Timestamp `json:"time"` // Scheduled start
+	TimeStatus      string              `json:"time_status"`
+	League          LeagueInfo          `json:"league"`
+	Home            TeamInfo            `json:"home"`
+	Away            TeamInfo            `json:"away"`
+	SS              string              `json:"ss"`
+	Scores          ScoresInfo          `json:"scores"`
+	Stats           StatsInfo           `json:"stats"`
+	Events          []EventInfo         `json:"events"`
+	Extra           ExtraInfo           `json:"extra"`
+	InplayCreatedAt timestamp.Timestamp `json:"inplay_created_at"` // In-play betting opened
+	InplayUpdatedAt timestamp.Timestamp `json:"inplay_updated_at"`
+	ConfirmedAt     timestamp.Timestamp `json:"confirmed_at"` // Result confirmed
+	Bet365ID        string              `json:"bet365_id"`
 }
 
 type LeagueInfo struct {
@@ -37,17 +49,51 @@ type TeamInfo struct {
 	CC      string `json:"cc"`
 }
 
-type ScoresInfo struct {
-	Set1 SetScore `json:"1"`
-	Set2 SetScore `json:"2"`
-	Set3 SetScore `json:"3"`
-	Set4 SetScore `json:"4"`
-	Set5 SetScore `json:"5"`
-}
+// ScoresInfo holds the score of every set played, first set first
+type ScoresInfo []SetScore
 
+// SetScore is the score of one set
 type SetScore struct {
-	Home string `json:"home"`
-	Away string `json:"away"`
+	Number int    `json:"-"` // 1 for the first set
+	Home   string `json:"home"`
+	Away   string `json:"away"`
+}
+
+// UnmarshalJSON decodes the "scores" object, keyed by set number, into the
+// sets in order. Sets without a score are skipped; an empty array is no sets.
+func (s *ScoresInfo) UnmarshalJSON(data []byte) error {
+	var keyed map[string]SetScore
+	if err := json.Unmarshal(data, &keyed); err != nil {
+		var list []SetScore
+		if json.Unmarshal(data, &list) != nil {
+			return err
+		}
+		keyed = make(map[string]SetScore)
+		for i, set := range list {
+			keyed[strconv.Itoa(i+1)] = set
+		}
+	}
+
+	sets := ScoresInfo{}
+	for key, set := range keyed {
+		number, err := strconv.Atoi(key)
+		if err != nil || number < 1 || (set.Home == "" && set.Away == "") {
+			continue
+		}
+		set.Number = number
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Number < sets[j].Number })
+
+	*s = sets
+	return nil
+}
+
+// Points returns the points of each team in the set
+func (s SetScore) Points() (int, int) {
+	home, _ := strconv.Atoi(s.Home)
+	away, _ := strconv.Atoi(s.Away)
+	return home, away
 }
 
 type StatsInfo struct {
@@ -67,49 +113,101 @@ type ExtraInfo struct {
 	Round      string `json:"round"`
 }
 
+// HandicapUnit is what a handicap line is applied to
+type HandicapUnit string
+
+// Handicap units
+const (
+	HandicapSets   HandicapUnit = "sets"   // Set margin of the match, e.g. -1.5 sets
+	HandicapPoints HandicapUnit = "points" // Points margin of the match or set, e.g. -4.5 points
+)
+
 // BetSelection represents a selection made in pre-match
 type BetSelection struct {
-	Market      string
-	MarketID    string
-	Selection   string
-	SelectionID string
-	Odds        float64
-	Handicap    string
-	StakeAmount float64 // Added stake amount for bet simulation
+	Market       string
+	MarketID     string
+	Selection    string
+	SelectionID  string
+	Odds         float64
+	FairOdds     float64   // Odds with the bookmaker margin removed; 0 when the market cannot be de-vigged
+	Price        odds.Odds // Ladder fraction the odds were derived from, used to settle returns exactly
+	Handicap     string
+	HandicapUnit HandicapUnit // Set for handicap selections only
+	TieOffered   bool         // A Tie option was priced, so team selections lose on a tie
+	StakeAmount  money.Money  // Added stake amount for bet simulation
+	Closed       bool         // Market was closed (open: 0) in the latest prematch snapshot
 }
 
 // EvaluationResult represents the result of a bet evaluation
 type EvaluationResult struct {
-	BetSelection     BetSelection
-	IsWin            bool
-	Explanation      string
-	ProfitLoss       float64 // Added profit/loss calculation
-	ReturnAmount     float64 // Added return amount calculation
-	ImpliedProbability float64 // Added implied probability
+	BetSelection       BetSelection
+	Outcome            settlement.Outcome
+	Explanation        string
+	ProfitLoss         money.Money // Added profit/loss calculation
+	ReturnAmount       money.Money // Added return amount calculation
+	ImpliedProbability float64     // Added implied probability
+	FairProbability    float64     // Implied probability without the bookmaker margin; 0 when unknown
+}
+
+// EvaluationContext bundles a match result with its statistics for settlement
+type EvaluationContext struct {
+	Result MatchResult
+	Stats  *MatchStatistics
 }
 
 // MatchStatistics represents key statistics from the match
 type MatchStatistics struct {
-	TotalMatchPoints   int
-	TotalSet1Points    int
-	TotalSet2Points    int
-	TotalSet3Points    int
-	TotalSet4Points    int
-	TotalSet5Points    int
-	HomeSetWins        int
-	AwaySetWins        int
-	MatchWinner        string
-	Set1Winner         string
-	Set2Winner         string
-	Set3Winner         string
-	Set4Winner         string
-	Set5Winner         string
-	Set1ExtraPoints    bool
-	Set2ExtraPoints    bool
-	Set3ExtraPoints    bool
-	Set4ExtraPoints    bool
-	Set5ExtraPoints    bool
-	CorrectSetScore    string
-	TotalSets          int
-	MaximumSets        int
+	TotalMatchPoints int
+	HomeMatchPoints  int
+	AwayMatchPoints  int
+	HomeSetWins      int
+	AwaySetWins      int
+	MatchWinner      string
+	Sets             []SetStatistics // Every set played, first set first
+	CorrectSetScore  string
+	TotalSets        int
+	MaximumSets      int
+	Events           []MatchEvent   // Typed timeline parsed from the result events
+	PointsWonOnServe *TeamStatistic // nil when the feed does not report it
+	LongestStreak    *TeamStatistic // Longest run of consecutive points; nil when not reported
+}
+
+// TeamStatistic is a statistic reported for both teams
+type TeamStatistic struct {
+	Home int
+	Away int
+}
+
+// Leader returns "1" or "2" for the team with the higher value, or "" on a tie
+func (t TeamStatistic) Leader() string {
+	switch {
+	case t.Home > t.Away:
+		return "1"
+	case t.Away > t.Home:
+		return "2"
+	}
+	return ""
+}
+
+// SetStatistics are the statistics of one set
+type SetStatistics struct {
+	Number       int
+	HomePoints   int
+	AwayPoints   int
+	TotalPoints  int
+	Winner       string // "1" or "2", empty while the set is level
+	TargetPoints int    // 25, or 15 in a deciding or golden set
+	ExtraPoints  bool   // Either team went past the target, e.g. 26-24
+	Deciding     bool   // Last set allowed by the format, e.g. set 5 of a best of 5
+	Golden       bool   // Played after the match to decide a two-legged tie; not part of the match score
+}
+
+// Set returns the statistics of set n (1-based), or false if it was not played
+func (m *MatchStatistics) Set(n int) (SetStatistics, bool) {
+	for _, set := range m.Sets {
+		if set.Number == n {
+			return set, true
+		}
+	}
+	return SetStatistics{}, false
 }
@@ -0,0 +1,239 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// Currency describes how amounts of a currency are counted and shown
+type Currency struct {
+	Code     string // ISO 4217 code, e.g. "USD"
+	Symbol   string
+	Decimals int // Digits of the minor unit, e.g. 2 for cents
+}
+
+// Currencies are keyed by code
+var Currencies = map[string]Currency{
+	"USD": {"USD", "$", 2},
+	"EUR": {"EUR", "€", 2},
+	"GBP": {"GBP", "£", 2},
+	"AUD": {"AUD", "A$", 2},
+	"CAD": {"CAD", "C$", 2},
+	"JPY": {"JPY", "¥", 0},
+}
+
+// DefaultCurrency is used for amounts given without a currency
+var DefaultCurrency = "USD"
+
+// Rounding decides how an amount that falls between two minor units is rounded
+type Rounding string
+
+// Rounding modes
+const (
+	Down     Rounding = "down"      // Towards zero, as bookmakers pay returns
+	Up       Rounding = "up"        // Away from zero
+	HalfUp   Rounding = "half-up"   // To the nearest unit, halves away from zero
+	HalfEven Rounding = "half-even" // To the nearest unit, halves to the even unit
+)
+
+// DefaultRounding rounds returns down to the minor unit, e.g. 18.3333 to 18.33
+var DefaultRounding = Down
+
+// Money is an amount held as a whole number of minor units of its currency,
+// e.g. 1833 cents for $18.33, so sums never drift. The zero value is zero in
+// the default currency.
+type Money struct {
+	units int64
+	code  string
+}
+
+// amount matches a decimal amount with an optional currency code, e.g. "10.50 GBP"
+var amount = regexp.MustCompile(`^([+-]?\d+(?:\.\d+)?)(?:\s*([A-Za-z]{3}))?$`)
+
+// New creates an amount from a number of minor units, e.g. New(1833, "USD") for $18.33
+func New(units int64, code string) (Money, error) {
+	currency, err := lookup(code)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, code: currency.Code}, nil
+}
+
+// Parse reads an amount with an optional currency code, e.g. "10", "10.50" or
+// "10.50 GBP". Amounts finer than the minor unit of the currency are an error.
+func Parse(text string) (Money, error) {
+	match := amount.FindStringSubmatch(strings.TrimSpace(text))
+	if match == nil {
+		return Money{}, fmt.Errorf("invalid amount %q", text)
+	}
+	currency, err := lookup(match[2])
+	if err != nil {
+		return Money{}, err
+	}
+	value, _ := new(big.Rat).SetString(match[1])
+	minor := value.Mul(value, scale(currency))
+	if !minor.IsInt() || !minor.Num().IsInt64() {
+		return Money{}, fmt.Errorf("invalid amount %q: not a whole number of %s minor units", text, currency.Code)
+	}
+	return Money{units: minor.Num().Int64(), code: currency.Code}, nil
+}
+
+// lookup finds a currency by code; an empty code is the default currency
+func lookup(code string) (Currency, error) {
+	if code == "" {
+		code = DefaultCurrency
+	}
+	currency, ok := Currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("unknown currency %q", code)
+	}
+	return currency, nil
+}
+
+// scale is the number of minor units in one unit of a currency, e.g. 100
+func scale(currency Currency) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currency.Decimals)), nil))
+}
+
+// Currency returns the currency of the amount
+func (m Money) Currency() Currency {
+	currency, _ := lookup(m.code)
+	return currency
+}
+
+// Units returns the amount in minor units, e.g. 1833 for $18.33
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// Sign returns -1, 0 or +1 as the amount is negative, zero or positive
+func (m Money) Sign() int {
+	switch {
+	case m.units < 0:
+		return -1
+	case m.units > 0:
+		return 1
+	}
+	return 0
+}
+
+// same returns the currency shared by two amounts. A zero amount takes the
+// currency of the other, so sums can start from the zero value.
+func same(a, b Money) string {
+	switch {
+	case a.code == b.code || b.IsZero():
+		return a.Currency().Code
+	case a.IsZero():
+		return b.Currency().Code
+	case a.Currency().Code == b.Currency().Code:
+		return a.Currency().Code
+	}
+	panic(fmt.Sprintf("money: cannot combine %s with %s", a.Currency().Code, b.Currency().Code))
+}
+
+// Add returns m plus other; both must be in the same currency
+func (m Money) Add(other Money) Money {
+	return Money{units: m.units + other.units, code: same(m, other)}
+}
+
+// Sub returns m minus other; both must be in the same currency
+func (m Money) Sub(other Money) Money {
+	return Money{units: m.units - other.units, code: same(m, other)}
+}
+
+// Mul multiplies the amount by an exact factor and rounds the result to the
+// minor unit, e.g. $10.00 x 11/6 is $18.33 rounded down
+func (m Money) Mul(factor *big.Rat, rounding Rounding) Money {
+	product := new(big.Rat).Mul(big.NewRat(m.units, 1), factor)
+	return Money{units: round(product, rounding), code: m.code}
+}
+
+// round rounds an exact number of minor units to a whole one
+func round(value *big.Rat, rounding Rounding) int64 {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	units := quotient.Int64()
+	if remainder.Sign() == 0 {
+		return units
+	}
+
+	away := int64(value.Sign())
+	twice := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2))
+	half := twice.Cmp(value.Denom())
+	switch rounding {
+	case Up:
+		return units + away
+	case HalfUp:
+		if half >= 0 {
+			return units + away
+		}
+	case HalfEven:
+		if half > 0 || (half == 0 && units%2 != 0) {
+			return units + away
+		}
+	}
+	return units
+}
+
+// Ratio returns m divided by other as a float, e.g. for a return on investment
+func (m Money) Ratio(other Money) float64 {
+	if other.IsZero() {
+		return 0
+	}
+	return float64(m.units) / float64(other.units)
+}
+
+// Float64 returns the amount in major units, e.g. 18.33
+func (m Money) Float64() float64 {
+	value, _ := new(big.Rat).Quo(big.NewRat(m.units, 1), scale(m.Currency())).Float64()
+	return value
+}
+
+// Decimal prints the amount in major units without a symbol, e.g. "-18.33"
+func (m Money) Decimal() string {
+	return new(big.Rat).Quo(big.NewRat(m.units, 1), scale(m.Currency())).FloatString(m.Currency().Decimals)
+}
+
+// String prints the amount with its currency symbol, e.g. "$18.33" or "-£5.00"
+func (m Money) String() string {
+	if m.units < 0 {
+		return "-" + m.Currency().Symbol + m.Neg().Decimal()
+	}
+	return m.Currency().Symbol + m.Decimal()
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return Money{units: -m.units, code: m.code}
+}
+
+// UnmarshalJSON reads an amount from a JSON number, in the default currency,
+// or from a string with an optional currency code, e.g. 10.5 or "10.50 GBP"
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid amount %s", data)
+		}
+		text = number.String()
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// MarshalJSON writes the amount as a string with its currency code, e.g. "18.33 USD"
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Decimal() + " " + m.Currency().Code)
+}
@@ -0,0 +1,139 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text    string
+		units   int64
+		code    string
+		wantErr bool
+	}{
+		{"10", 1000, "USD", false},
+		{"10.5", 1050, "USD", false},
+		{" 10.50 GBP ", 1050, "GBP", false},
+		{"10.50gbp", 1050, "GBP", false},
+		{"-2.25 EUR", -225, "EUR", false},
+		{"1500 JPY", 1500, "JPY", false},
+		{"0", 0, "USD", false},
+		{"10.505", 0, "", true},
+		{"10.5 JPY", 0, "", true},
+		{"10 XYZ", 0, "", true},
+		{"ten", 0, "", true},
+		{"$10", 0, "", true},
+		{"", 0, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			got, err := Parse(test.text)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Parse(%q) error = %v, want error %t", test.text, err, test.wantErr)
+			}
+			if !test.wantErr && (got.Units() != test.units || got.Currency().Code != test.code) {
+				t.Errorf("Parse(%q) = %d %s, want %d %s", test.text, got.Units(), got.Currency().Code, test.units, test.code)
+			}
+		})
+	}
+}
+
+func TestMulRounding(t *testing.T) {
+	tests := []struct {
+		units    int64
+		num, den int64
+		rounding Rounding
+		want     int64
+	}{
+		{1000, 11, 6, Down, 1833},
+		{1000, 11, 6, Up, 1834},
+		{1000, 11, 6, HalfUp, 1833},
+		{1000, 11, 6, HalfEven, 1833},
+		{1000, 13, 6, HalfUp, 2167},
+		{1, 5, 2, Down, 2},
+		{1, 5, 2, Up, 3},
+		{1, 5, 2, HalfUp, 3},
+		{1, 5, 2, HalfEven, 2},
+		{1, 7, 2, HalfEven, 4},
+		{-1, 5, 2, Down, -2},
+		{-1, 5, 2, Up, -3},
+		{-1, 5, 2, HalfUp, -3},
+		{-1, 5, 2, HalfEven, -2},
+		{300, 2, 3, Down, 200},
+		{1000, 0, 1, Up, 0},
+	}
+
+	for _, test := range tests {
+		amount := Money{units: test.units, code: "USD"}
+		got := amount.Mul(big.NewRat(test.num, test.den), test.rounding)
+		if got.Units() != test.want {
+			t.Errorf("%d x %d/%d rounded %s = %d, want %d", test.units, test.num, test.den, test.rounding, got.Units(), test.want)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		units int64
+		code  string
+		want  string
+	}{
+		{1833, "USD", "$18.33"},
+		{-500, "GBP", "-£5.00"},
+		{5, "EUR", "€0.05"},
+		{1500, "JPY", "¥1500"},
+		{0, "USD", "$0.00"},
+	}
+
+	for _, test := range tests {
+		amount, err := New(test.units, test.code)
+		if err != nil {
+			t.Fatalf("New(%d, %s): %v", test.units, test.code, err)
+		}
+		if got := amount.String(); got != test.want {
+			t.Errorf("%d %s = %q, want %q", test.units, test.code, got, test.want)
+		}
+	}
+}
+
+func TestAddAdoptsCurrencyOfNonZero(t *testing.T) {
+	pounds, _ := Parse("5 GBP")
+	if got := (Money{}).Add(pounds); got.Currency().Code != "GBP" || got.Units() != 500 {
+		t.Errorf("zero + %s = %s, want £5.00", pounds, got)
+	}
+	if got := pounds.Sub(Money{}); got.Currency().Code != "GBP" || got.Units() != 500 {
+		t.Errorf("%s - zero = %s, want £5.00", pounds, got)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{`10.5`, `"10.50 USD"`, false},
+		{`"10.50 GBP"`, `"10.50 GBP"`, false},
+		{`"3 JPY"`, `"3 JPY"`, false},
+		{`true`, ``, true},
+		{`"10.505"`, ``, true},
+	}
+
+	for _, test := range tests {
+		var amount Money
+		err := json.Unmarshal([]byte(test.data), &amount)
+		if (err != nil) != test.wantErr {
+			t.Fatalf("Unmarshal(%s) error = %v, want error %t", test.data, err, test.wantErr)
+		}
+		if test.wantErr {
+			continue
+		}
+		got, err := json.Marshal(amount)
+		if err != nil || string(got) != test.want {
+			t.Errorf("%s marshals to %s (%v), want %s", test.data, got, err, test.want)
+		}
+	}
+}
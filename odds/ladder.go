@@ -0,0 +1,118 @@
+package odds
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/yesetoda/bet365-evaluator-go/report"
+)
+
+// Rung is a price of the bookmaker's fractional ladder, kept as quoted, e.g. 4/6
+type Rung struct {
+	Quote string
+	Odds  Odds
+}
+
+// Decimal returns the decimal price the bookmaker derives from the fraction:
+// the return per unit staked rounded down to 2 decimals, e.g. 1.90 for 10/11
+func (r Rung) Decimal() float64 {
+	return float64(r.cents()) / 100
+}
+
+// cents returns the derived decimal price in hundredths
+func (r Rung) cents() int64 {
+	return 100 + r.Odds.num*100/r.Odds.den
+}
+
+// ladderQuotes are the fractions the bookmaker quotes, shortest first
+var ladderQuotes = []string{
+	"1/100", "1/50", "1/33", "1/25", "1/20", "1/16", "1/14", "1/12",
+	"1/11", "1/10", "1/9", "1/8", "2/15", "1/7", "2/13", "1/6", "2/11", "1/5",
+	"2/9", "1/4", "2/7", "3/10", "1/3", "4/11", "2/5", "4/9", "1/2", "8/15",
+	"4/7", "8/13", "4/6", "7/10", "8/11", "3/4", "4/5", "5/6", "10/11", "20/21",
+	"1/1", "21/20", "11/10", "6/5", "5/4", "13/10", "11/8", "7/5", "6/4", "8/5",
+	"13/8", "7/4", "15/8", "2/1", "21/10", "9/4", "12/5", "5/2", "13/5", "11/4",
+	"3/1", "16/5", "10/3", "7/2", "15/4", "4/1", "9/2", "5/1", "11/2", "6/1",
+	"13/2", "7/1", "15/2", "8/1", "17/2", "9/1", "10/1", "11/1", "12/1", "14/1",
+	"16/1", "18/1", "20/1", "22/1", "25/1", "28/1", "33/1", "40/1", "50/1", "66/1",
+	"80/1", "100/1", "125/1", "150/1", "200/1", "250/1", "300/1", "400/1", "500/1",
+}
+
+// Ladder is the standard Bet365 fractional ladder, shortest price first
+var Ladder = newLadder(ladderQuotes)
+
+// newLadder parses the quotes of a ladder
+func newLadder(quotes []string) []Rung {
+	ladder := []Rung{}
+	for _, quote := range quotes {
+		price, err := Parse(quote, Fractional)
+		if err != nil {
+			panic(fmt.Sprintf("invalid ladder price: %v", err))
+		}
+		ladder = append(ladder, Rung{Quote: quote, Odds: price})
+	}
+	sort.SliceStable(ladder, func(i, j int) bool { return ladder[i].cents() < ladder[j].cents() })
+	return ladder
+}
+
+// Snap returns the ladder rung nearest to a decimal price, and whether the
+// decimal is exactly the one derived from that rung, e.g. 1.83 snaps to 5/6
+func Snap(decimal float64) (Rung, bool) {
+	cents := int64(math.Round(decimal * 100))
+	nearest := Ladder[0]
+	for _, rung := range Ladder {
+		if rung.cents() == cents {
+			return rung, true
+		}
+		if abs(rung.cents()-cents) < abs(nearest.cents()-cents) {
+			nearest = rung
+		}
+	}
+	return nearest, false
+}
+
+// FromLadder returns the ladder fraction a quoted decimal price was derived
+// from. A decimal off the ladder is taken as exact and ok is false; one that
+// pays no profit gives the zero Odds.
+func FromLadder(decimal float64) (price Odds, ok bool) {
+	if rung, ok := Snap(decimal); ok {
+		return rung.Odds, true
+	}
+	price, _ = FromDecimal(decimal)
+	return price, false
+}
+
+// Quote is a decimal price quoted for a selection
+type Quote struct {
+	Market    string
+	Selection string
+	Decimal   float64
+}
+
+// PrintOffLadder prints the quotes whose decimal price is not derived from a
+// ladder fraction, with the nearest rung of the ladder
+func PrintOffLadder(quotes []Quote) {
+	off := []Quote{}
+	for _, quote := range quotes {
+		if _, ok := Snap(quote.Decimal); !ok {
+			off = append(off, quote)
+		}
+	}
+
+	fmt.Println("\n==================== FRACTIONAL LADDER ====================")
+	fmt.Printf("%d of %d quoted prices are on the ladder\n", len(quotes)-len(off), len(quotes))
+	for _, quote := range off {
+		rung, _ := Snap(quote.Decimal)
+		fmt.Printf("  %-60s %8.2f  off ladder, nearest %s (%.2f)\n",
+			report.Truncate(quote.Market+" - "+quote.Selection, 60), quote.Decimal, rung.Quote, rung.Decimal())
+	}
+}
+
+// abs returns the absolute value of n
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
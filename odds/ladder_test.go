@@ -0,0 +1,96 @@
+package odds
+
+import "testing"
+
+func TestRungDecimal(t *testing.T) {
+	// The feed quotes the return per unit rounded down, not to nearest
+	tests := []struct {
+		quote string
+		want  float64
+	}{
+		{"1/100", 1.01},
+		{"2/7", 1.28},
+		{"8/13", 1.61},
+		{"4/6", 1.66},
+		{"8/11", 1.72},
+		{"5/6", 1.83},
+		{"10/11", 1.90},
+		{"20/21", 1.95},
+		{"13/8", 2.62},
+		{"15/2", 8.50},
+		{"500/1", 501},
+	}
+
+	for _, test := range tests {
+		price, err := Parse(test.quote, Fractional)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", test.quote, err)
+		}
+		if got := (Rung{Quote: test.quote, Odds: price}).Decimal(); got != test.want {
+			t.Errorf("%s = %.2f, want %.2f", test.quote, got, test.want)
+		}
+	}
+}
+
+func TestLadderOrder(t *testing.T) {
+	for i := 1; i < len(Ladder); i++ {
+		if Ladder[i].cents() <= Ladder[i-1].cents() {
+			t.Errorf("%s (%.2f) does not follow %s (%.2f)",
+				Ladder[i].Quote, Ladder[i].Decimal(), Ladder[i-1].Quote, Ladder[i-1].Decimal())
+		}
+	}
+}
+
+func TestSnap(t *testing.T) {
+	tests := []struct {
+		decimal float64
+		quote   string
+		exact   bool
+	}{
+		// Prices quoted by the sample feeds
+		{1.44, "4/9", true},
+		{1.57, "4/7", true},
+		{1.66, "4/6", true},
+		{1.83, "5/6", true},
+		{1.90, "10/11", true},
+		{2.62, "13/8", true},
+		{7.00, "6/1", true},
+		{8.50, "15/2", true},
+		{9.00, "8/1", true},
+		// Off the ladder
+		{1.67, "4/6", false},
+		{1.84, "5/6", false},
+		{1.91, "10/11", false},
+		{1.001, "1/100", false},
+		{900, "500/1", false},
+	}
+
+	for _, test := range tests {
+		rung, exact := Snap(test.decimal)
+		if rung.Quote != test.quote || exact != test.exact {
+			t.Errorf("Snap(%v) = %s, %t, want %s, %t", test.decimal, rung.Quote, exact, test.quote, test.exact)
+		}
+	}
+}
+
+func TestFromLadder(t *testing.T) {
+	tests := []struct {
+		decimal  float64
+		num, den int64
+		ok       bool
+	}{
+		{1.83, 5, 6, true},
+		{1.90, 10, 11, true},
+		{2.62, 13, 8, true},
+		{1.84, 21, 25, false},
+		{1.00, 0, 0, false},
+	}
+
+	for _, test := range tests {
+		price, ok := FromLadder(test.decimal)
+		if num, den := price.Profit(); num != test.num || den != test.den || ok != test.ok {
+			t.Errorf("FromLadder(%v) = %d/%d, %t, want %d/%d, %t",
+				test.decimal, num, den, ok, test.num, test.den, test.ok)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package odds
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format is a way of quoting a price
+type Format string
+
+// Odds formats
+const (
+	Decimal    Format = "decimal"    // Total return per unit staked, e.g. 1.83
+	Fractional Format = "fractional" // Profit per unit staked as a fraction, e.g. 5/6
+	American   Format = "american"   // Profit on 100 staked, or stake to win 100, e.g. +150 or -120
+	HongKong   Format = "hongkong"   // Profit per unit staked, e.g. 0.83
+	Malay      Format = "malay"      // Profit per unit up to evens, -1/profit beyond, e.g. 0.83 or -0.67
+	Indonesian Format = "indonesian" // Profit per unit from evens, -1/profit below, e.g. 1.50 or -1.20
+)
+
+// Formats lists every odds format
+var Formats = []Format{Decimal, Fractional, American, HongKong, Malay, Indonesian}
+
+// MaxDenominator bounds the denominator of fractional prices. Fractions with
+// a larger denominator are shown as their best rational approximation.
+const MaxDenominator = 10000
+
+// Odds is a price held as its exact profit per unit staked, e.g. 83/100 for
+// decimal odds of 1.83 or 5/6 for fractional odds of 5/6, so converting between
+// formats loses nothing. Parsing a price and formatting it in the same format
+// gives back the same price.
+type Odds struct {
+	num, den int64 // Profit as a reduced fraction; den is 0 for the zero value
+}
+
+// number matches a signed decimal number, e.g. "-1.20"
+var number = regexp.MustCompile(`^[+-]?\d+(\.\d+)?$`)
+
+// parseNumber parses a decimal number exactly
+func parseNumber(text string) (*big.Rat, error) {
+	text = strings.TrimSpace(text)
+	if !number.MatchString(text) {
+		return nil, fmt.Errorf("invalid number %q", text)
+	}
+	value, ok := new(big.Rat).SetString(text)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %q", text)
+	}
+	return value, nil
+}
+
+// fromProfit creates odds from an exact profit per unit staked
+func fromProfit(profit *big.Rat) (Odds, error) {
+	if profit.Sign() <= 0 {
+		return Odds{}, fmt.Errorf("odds must pay a profit, got %s", profit.RatString())
+	}
+	if !profit.Num().IsInt64() || !profit.Denom().IsInt64() {
+		return Odds{}, fmt.Errorf("odds %s are out of range", profit.RatString())
+	}
+	return Odds{num: profit.Num().Int64(), den: profit.Denom().Int64()}, nil
+}
+
+// New creates odds paying num/den profit per unit staked, e.g. New(5, 6) for 5/6
+func New(num, den int64) (Odds, error) {
+	if den == 0 {
+		return Odds{}, fmt.Errorf("invalid fraction %d/%d", num, den)
+	}
+	return fromProfit(big.NewRat(num, den))
+}
+
+// FromDecimal creates odds from a decimal price, taking its shortest decimal
+// representation as exact, e.g. 1.83 pays 83/100
+func FromDecimal(decimal float64) (Odds, error) {
+	return Parse(strconv.FormatFloat(decimal, 'f', -1, 64), Decimal)
+}
+
+// Parse reads a price quoted in the given format
+func Parse(text string, format Format) (Odds, error) {
+	text = strings.TrimSpace(text)
+	if format == Fractional {
+		switch strings.ToLower(text) {
+		case "evs", "evens":
+			return New(1, 1)
+		}
+		numerator, denominator, ok := strings.Cut(text, "/")
+		num, numErr := strconv.ParseInt(numerator, 10, 64)
+		den, denErr := strconv.ParseInt(denominator, 10, 64)
+		if !ok || numErr != nil || denErr != nil || den <= 0 {
+			return Odds{}, fmt.Errorf("invalid fractional odds %q", text)
+		}
+		return New(num, den)
+	}
+
+	value, err := parseNumber(text)
+	if err != nil {
+		return Odds{}, fmt.Errorf("invalid %s odds: %v", format, err)
+	}
+	one := big.NewRat(1, 1)
+	switch format {
+	case Decimal:
+		return fromProfit(value.Sub(value, one))
+	case American:
+		// +150 wins 150 on 100 staked; -120 stakes 120 to win 100
+		hundred := big.NewRat(100, 1)
+		if new(big.Rat).Abs(value).Cmp(hundred) < 0 {
+			return Odds{}, fmt.Errorf("invalid american odds %q: must be at least 100 either side", text)
+		}
+		if value.Sign() > 0 {
+			return fromProfit(value.Quo(value, hundred))
+		}
+		return fromProfit(value.Quo(hundred, value.Neg(value)))
+	case HongKong:
+		return fromProfit(value)
+	case Malay, Indonesian:
+		// Negative prices quote the stake needed to win one unit
+		if value.Sign() < 0 {
+			return fromProfit(value.Quo(one, value.Neg(value)))
+		}
+		return fromProfit(value)
+	}
+	return Odds{}, fmt.Errorf("unknown odds format %q", format)
+}
+
+// profit returns the exact profit per unit staked
+func (o Odds) profit() *big.Rat {
+	if o.den == 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(o.num, o.den)
+}
+
+// IsZero reports whether the odds were never set
+func (o Odds) IsZero() bool {
+	return o.den == 0
+}
+
+// Profit returns the profit per unit staked as a reduced fraction
+func (o Odds) Profit() (num, den int64) {
+	return o.num, o.den
+}
+
+// Decimal returns the decimal price, e.g. 1.83
+func (o Odds) Decimal() float64 {
+	value, _ := new(big.Rat).Add(o.profit(), big.NewRat(1, 1)).Float64()
+	return value
+}
+
+// ImpliedProbability returns the probability implied by the price, e.g. 0.5464 for 1.83
+func (o Odds) ImpliedProbability() float64 {
+	if o.IsZero() {
+		return 0
+	}
+	return 1 / o.Decimal()
+}
+
+// Fraction returns the best rational approximation of the profit with a
+// denominator of at most maxDen, e.g. 5/6 for 1.8333333 and maxDen 100
+func (o Odds) Fraction(maxDen int64) (num, den int64) {
+	if o.den <= maxDen {
+		return o.num, o.den
+	}
+
+	// Walk the continued fraction of the profit until the next convergent
+	// would exceed the bound
+	p0, q0, p1, q1 := int64(0), int64(1), int64(1), int64(0)
+	n, d := o.num, o.den
+	for {
+		a := n / d
+		q2 := q0 + a*q1
+		if q2 > maxDen {
+			break
+		}
+		p0, q0, p1, q1 = p1, q1, p0+a*p1, q2
+		n, d = d, n-a*d
+	}
+
+	// The best approximation is the last convergent or the largest
+	// semiconvergent after it
+	k := (maxDen - q0) / q1
+	target := o.profit()
+	semiconvergent := big.NewRat(p0+k*p1, q0+k*q1)
+	convergent := big.NewRat(p1, q1)
+	semiDistance := new(big.Rat).Abs(new(big.Rat).Sub(semiconvergent, target))
+	distance := new(big.Rat).Abs(new(big.Rat).Sub(convergent, target))
+	if semiDistance.Cmp(distance) < 0 {
+		num, den = p0+k*p1, q0+k*q1
+	} else {
+		num, den = p1, q1
+	}
+	if num == 0 {
+		// Never round a price down to no profit
+		return 1, maxDen
+	}
+	return num, den
+}
+
+// formatNumber prints a number exactly when it has a finite decimal expansion,
+// with at least minDigits decimals, and to 2 decimals otherwise
+func formatNumber(value *big.Rat, minDigits int) string {
+	digits, den := 0, new(big.Int).Set(value.Denom())
+	two, five, ten := big.NewInt(2), big.NewInt(5), big.NewInt(10)
+	for den.Cmp(big.NewInt(1)) != 0 {
+		switch {
+		case new(big.Int).Mod(den, ten).Sign() == 0:
+			den.Quo(den, ten)
+		case new(big.Int).Mod(den, two).Sign() == 0:
+			den.Quo(den, two)
+		case new(big.Int).Mod(den, five).Sign() == 0:
+			den.Quo(den, five)
+		default:
+			return value.FloatString(max(minDigits, 2))
+		}
+		digits++
+	}
+	return value.FloatString(max(minDigits, digits))
+}
+
+// Format prints the price in the given format, e.g. "1.83", "83/100", "-120.48"
+func (o Odds) Format(format Format) string {
+	if o.IsZero() {
+		return "-"
+	}
+	profit := o.profit()
+	one := big.NewRat(1, 1)
+	switch format {
+	case Decimal:
+		return formatNumber(new(big.Rat).Add(profit, one), 2)
+	case Fractional:
+		num, den := o.Fraction(MaxDenominator)
+		return fmt.Sprintf("%d/%d", num, den)
+	case American:
+		if profit.Cmp(one) >= 0 {
+			return "+" + formatNumber(new(big.Rat).Mul(profit, big.NewRat(100, 1)), 0)
+		}
+		return "-" + formatNumber(new(big.Rat).Quo(big.NewRat(100, 1), profit), 0)
+	case HongKong:
+		return formatNumber(profit, 2)
+	case Malay:
+		if profit.Cmp(one) <= 0 {
+			return formatNumber(profit, 2)
+		}
+		return "-" + formatNumber(new(big.Rat).Quo(one, profit), 2)
+	case Indonesian:
+		if profit.Cmp(one) >= 0 {
+			return formatNumber(profit, 2)
+		}
+		return "-" + formatNumber(new(big.Rat).Quo(one, profit), 2)
+	}
+	return fmt.Sprintf("unknown odds format %q", format)
+}
+
+// String prints the decimal price
+func (o Odds) String() string {
+	return o.Format(Decimal)
+}
@@ -0,0 +1,176 @@
+package odds
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text     string
+		format   Format
+		num, den int64
+		wantErr  bool
+	}{
+		{"1.83", Decimal, 83, 100, false},
+		{" 2.50 ", Decimal, 3, 2, false},
+		{"1.00", Decimal, 0, 0, true},
+		{"0.5", Decimal, 0, 0, true},
+		{"1,83", Decimal, 0, 0, true},
+		{"5/6", Fractional, 5, 6, false},
+		{"10/4", Fractional, 5, 2, false},
+		{"Evs", Fractional, 1, 1, false},
+		{"5/0", Fractional, 0, 0, true},
+		{"5", Fractional, 0, 0, true},
+		{"+150", American, 3, 2, false},
+		{"100", American, 1, 1, false},
+		{"-120", American, 5, 6, false},
+		{"-200", American, 1, 2, false},
+		{"+99", American, 0, 0, true},
+		{"0.83", HongKong, 83, 100, false},
+		{"0.5", Malay, 1, 2, false},
+		{"-0.5", Malay, 2, 1, false},
+		{"1.5", Indonesian, 3, 2, false},
+		{"-1.25", Indonesian, 4, 5, false},
+		{"1.83", Format("roman"), 0, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format)+" "+test.text, func(t *testing.T) {
+			got, err := Parse(test.text, test.format)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Parse(%q, %s) error = %v, want error %t", test.text, test.format, err, test.wantErr)
+			}
+			if num, den := got.Profit(); !test.wantErr && (num != test.num || den != test.den) {
+				t.Errorf("Parse(%q, %s) = %d/%d, want %d/%d", test.text, test.format, num, den, test.num, test.den)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		want     map[Format]string
+	}{
+		{83, 100, map[Format]string{
+			Decimal: "1.83", Fractional: "83/100", American: "-120.48",
+			HongKong: "0.83", Malay: "0.83", Indonesian: "-1.20",
+		}},
+		{5, 6, map[Format]string{
+			Decimal: "1.83", Fractional: "5/6", American: "-120",
+			HongKong: "0.83", Malay: "0.83", Indonesian: "-1.20",
+		}},
+		{3, 2, map[Format]string{
+			Decimal: "2.50", Fractional: "3/2", American: "+150",
+			HongKong: "1.50", Malay: "-0.67", Indonesian: "1.50",
+		}},
+		{1, 1, map[Format]string{
+			Decimal: "2.00", Fractional: "1/1", American: "+100",
+			HongKong: "1.00", Malay: "1.00", Indonesian: "1.00",
+		}},
+		{1, 40, map[Format]string{
+			Decimal: "1.025", Fractional: "1/40", American: "-4000",
+			HongKong: "0.025", Malay: "0.025", Indonesian: "-40.00",
+		}},
+	}
+
+	for _, test := range tests {
+		price, err := New(test.num, test.den)
+		if err != nil {
+			t.Fatalf("New(%d, %d): %v", test.num, test.den, err)
+		}
+		for _, format := range Formats {
+			if got := price.Format(format); got != test.want[format] {
+				t.Errorf("%d/%d in %s = %q, want %q", test.num, test.den, format, got, test.want[format])
+			}
+		}
+	}
+
+	if got := (Odds{}).Format(Decimal); got != "-" {
+		t.Errorf("zero odds = %q, want \"-\"", got)
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	tests := []struct {
+		text   string
+		format Format
+	}{
+		{"1.83", Decimal},
+		{"2.50", Decimal},
+		{"1.025", Decimal},
+		{"5/6", Fractional},
+		{"10/3", Fractional},
+		{"+150", American},
+		{"-120", American},
+		{"0.83", HongKong},
+		{"-0.67", Malay},
+		{"0.40", Malay},
+		{"-1.20", Indonesian},
+		{"1.50", Indonesian},
+	}
+
+	for _, test := range tests {
+		price, err := Parse(test.text, test.format)
+		if err != nil {
+			t.Fatalf("Parse(%q, %s): %v", test.text, test.format, err)
+		}
+		if got := price.Format(test.format); got != test.text {
+			t.Errorf("%s %q printed back as %q", test.format, test.text, got)
+		}
+	}
+}
+
+func TestDecimalFractionalRoundTrip(t *testing.T) {
+	tests := []struct {
+		decimal    float64
+		fractional string
+	}{
+		{1.83, "83/100"},
+		{2.5, "3/2"},
+		{2, "1/1"},
+		{1.025, "1/40"},
+		{34, "33/1"},
+		{1.001, "1/1000"},
+	}
+
+	for _, test := range tests {
+		price, err := FromDecimal(test.decimal)
+		if err != nil {
+			t.Fatalf("FromDecimal(%v): %v", test.decimal, err)
+		}
+		if got := price.Format(Fractional); got != test.fractional {
+			t.Errorf("FromDecimal(%v) = %s, want %s", test.decimal, got, test.fractional)
+		}
+		back, err := Parse(test.fractional, Fractional)
+		if err != nil {
+			t.Fatalf("Parse(%q, fractional): %v", test.fractional, err)
+		}
+		if back != price || back.Decimal() != test.decimal {
+			t.Errorf("%s reads back as %v, want %v", test.fractional, back.Decimal(), test.decimal)
+		}
+	}
+}
+
+func TestFraction(t *testing.T) {
+	tests := []struct {
+		num, den int64
+		maxDen   int64
+		wantNum  int64
+		wantDen  int64
+	}{
+		{5, 6, 100, 5, 6},
+		{833333, 1000000, 100, 5, 6},
+		{314159, 100000, 10, 22, 7},
+		{1, 100000, 1000, 1, 1000},
+	}
+
+	for _, test := range tests {
+		price, err := New(test.num, test.den)
+		if err != nil {
+			t.Fatalf("New(%d, %d): %v", test.num, test.den, err)
+		}
+		if num, den := price.Fraction(test.maxDen); num != test.wantNum || den != test.wantDen {
+			t.Errorf("%d/%d within %d = %d/%d, want %d/%d",
+				test.num, test.den, test.maxDen, num, den, test.wantNum, test.wantDen)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+)
+
+// Method removes the bookmaker margin from the implied probabilities of a
+// market whose outcomes are mutually exclusive
+type Method string
+
+// De-vigging methods
+const (
+	Proportional Method = "proportional" // Scales every implied probability by the same factor
+	Power        Method = "power"        // Raises every implied probability to the same power
+	Shin         Method = "shin"         // Models the margin as protection against insider trading
+	OddsRatio    Method = "odds-ratio"   // Divides the odds (p/(1-p)) of every outcome by the same ratio
+)
+
+// Methods lists every de-vigging method, in report order
+var Methods = []Method{Proportional, Power, Shin, OddsRatio}
+
+// DefaultMethod is used where a single fair price is shown
+const DefaultMethod = Shin
+
+// Overround is the sum of the implied probabilities of a market's outcomes,
+// e.g. 1.05 for a market with a 5% margin
+func Overround(odds []float64) float64 {
+	total := 0.0
+	for _, o := range odds {
+		total += 1 / o
+	}
+	return total
+}
+
+// Devig returns the margin-free probability of every outcome of a market.
+// The market needs at least two outcomes, all priced above 1.
+func Devig(odds []float64, method Method) ([]float64, error) {
+	if len(odds) < 2 {
+		return nil, fmt.Errorf("need at least 2 outcomes, got %d", len(odds))
+	}
+	implied := make([]float64, len(odds))
+	for i, o := range odds {
+		if o <= 1 {
+			return nil, fmt.Errorf("odds %.2f must be above 1", o)
+		}
+		implied[i] = 1 / o
+	}
+	overround := Overround(odds)
+
+	probabilities := make([]float64, len(implied))
+	switch method {
+	case Proportional:
+		for i, q := range implied {
+			probabilities[i] = q / overround
+		}
+	case Power:
+		k := solve(func(k float64) float64 {
+			total := 0.0
+			for _, q := range implied {
+				total += math.Pow(q, k)
+			}
+			return total
+		}, 0, 1000)
+		for i, q := range implied {
+			probabilities[i] = math.Pow(q, k)
+		}
+	case Shin:
+		// Shin's insider share z is 0 for a market without margin
+		z := 0.0
+		if overround > 1 {
+			z = solve(func(z float64) float64 {
+				total := 0.0
+				for _, q := range implied {
+					total += shinProbability(q, z, overround)
+				}
+				return total
+			}, 0, 1-1e-12)
+		}
+		for i, q := range implied {
+			probabilities[i] = shinProbability(q, z, overround)
+		}
+	case OddsRatio:
+		c := solve(func(c float64) float64 {
+			total := 0.0
+			for _, q := range implied {
+				total += q / (c + q - c*q)
+			}
+			return total
+		}, 0, 1e6)
+		for i, q := range implied {
+			probabilities[i] = q / (c + q - c*q)
+		}
+	default:
+		return nil, fmt.Errorf("unknown de-vigging method %q", method)
+	}
+
+	// Remove the residue left by the solvers
+	total := 0.0
+	for _, p := range probabilities {
+		total += p
+	}
+	for i := range probabilities {
+		probabilities[i] /= total
+	}
+	return probabilities, nil
+}
+
+// DevigOdds returns the margin-free decimal odds of every outcome of a market
+func DevigOdds(odds []float64, method Method) ([]float64, error) {
+	probabilities, err := Devig(odds, method)
+	if err != nil {
+		return nil, err
+	}
+	fair := make([]float64, len(probabilities))
+	for i, p := range probabilities {
+		fair[i] = FairOdds(p, 0)
+	}
+	return fair, nil
+}
+
+// shinProbability is the probability of an outcome with implied probability q
+// under Shin's model with insider share z
+func shinProbability(q, z, overround float64) float64 {
+	return (math.Sqrt(z*z+4*(1-z)*q*q/overround) - z) / (2 * (1 - z))
+}
+
+// solve finds the x in [lo, hi] at which the decreasing function f equals 1
+func solve(f func(x float64) float64, lo, hi float64) float64 {
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if f(mid) > 1 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
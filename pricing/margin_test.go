@@ -0,0 +1,104 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOverround(t *testing.T) {
+	tests := []struct {
+		odds []float64
+		want float64
+	}{
+		{[]float64{2, 2}, 1},
+		{[]float64{1.5, 3}, 1},
+		{[]float64{1.91, 1.91}, 1.0471},
+		{[]float64{1.5, 2.5}, 1.0667},
+	}
+
+	for _, test := range tests {
+		if got := Overround(test.odds); math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("Overround(%v) = %.4f, want %.4f", test.odds, got, test.want)
+		}
+	}
+}
+
+func TestDevig(t *testing.T) {
+	tests := []struct {
+		name   string
+		odds   []float64
+		method Method
+		want   []float64
+	}{
+		{"proportional without margin", []float64{1.5, 3}, Proportional, []float64{0.6667, 0.3333}},
+		{"power without margin", []float64{1.5, 3}, Power, []float64{0.6667, 0.3333}},
+		{"shin without margin", []float64{1.5, 3}, Shin, []float64{0.6667, 0.3333}},
+		{"odds-ratio without margin", []float64{1.5, 3}, OddsRatio, []float64{0.6667, 0.3333}},
+		{"proportional even market", []float64{1.91, 1.91}, Proportional, []float64{0.5, 0.5}},
+		{"power even market", []float64{1.91, 1.91}, Power, []float64{0.5, 0.5}},
+		{"shin even market", []float64{1.91, 1.91}, Shin, []float64{0.5, 0.5}},
+		{"odds-ratio even market", []float64{1.91, 1.91}, OddsRatio, []float64{0.5, 0.5}},
+		{"proportional two-way", []float64{1.5, 2.5}, Proportional, []float64{0.6250, 0.3750}},
+		{"power two-way", []float64{1.5, 2.5}, Power, []float64{0.6379, 0.3621}},
+		{"shin two-way", []float64{1.5, 2.5}, Shin, []float64{0.6333, 0.3667}},
+		{"odds-ratio two-way", []float64{1.5, 2.5}, OddsRatio, []float64{0.6340, 0.3660}},
+		{"proportional three-way", []float64{1.25, 4.5, 9}, Proportional, []float64{0.7059, 0.1961, 0.0980}},
+		{"power three-way", []float64{1.25, 4.5, 9}, Power, []float64{0.7647, 0.1640, 0.0713}},
+		{"shin three-way", []float64{1.25, 4.5, 9}, Shin, []float64{0.7423, 0.1814, 0.0763}},
+		{"odds-ratio three-way", []float64{1.25, 4.5, 9}, OddsRatio, []float64{0.7444, 0.1722, 0.0834}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := Devig(test.odds, test.method)
+			if err != nil {
+				t.Fatalf("Devig(%v, %s): %v", test.odds, test.method, err)
+			}
+			total := 0.0
+			for i, p := range got {
+				total += p
+				if math.Abs(p-test.want[i]) > 1e-4 {
+					t.Errorf("Devig(%v, %s) = %.4f, want %.4f", test.odds, test.method, got, test.want)
+					break
+				}
+			}
+			if math.Abs(total-1) > 1e-9 {
+				t.Errorf("Devig(%v, %s) sums to %v, want 1", test.odds, test.method, total)
+			}
+		})
+	}
+}
+
+func TestDevigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		odds   []float64
+		method Method
+	}{
+		{"single outcome", []float64{1.5}, Proportional},
+		{"no outcomes", nil, Shin},
+		{"odds of 1", []float64{1, 3}, Power},
+		{"odds below 1", []float64{0.5, 3}, OddsRatio},
+		{"unknown method", []float64{1.5, 2.5}, Method("additive")},
+	}
+
+	for _, test := range tests {
+		if _, err := Devig(test.odds, test.method); err == nil {
+			t.Errorf("%s: Devig(%v, %s) returned no error", test.name, test.odds, test.method)
+		}
+	}
+}
+
+func TestDevigOdds(t *testing.T) {
+	got, err := DevigOdds([]float64{1.5, 2.5}, Proportional)
+	if err != nil {
+		t.Fatalf("DevigOdds: %v", err)
+	}
+	want := []float64{1.6, 2.6667}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-4 {
+			t.Errorf("DevigOdds([1.5 2.5], proportional) = %.4f, want %.4f", got, want)
+			break
+		}
+	}
+}
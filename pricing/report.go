@@ -0,0 +1,84 @@
+package pricing
+
+import (
+	"fmt"
+
+	"github.com/yesetoda/bet365-evaluator-go/report"
+)
+
+// Book is a set of mutually exclusive selections of a market, e.g. both
+// sides of one over/under line
+type Book struct {
+	Market     string
+	Selections []string
+	Odds       []float64
+}
+
+// Add appends a selection and its quoted odds to the book
+func (b *Book) Add(selection string, odds float64) {
+	b.Selections = append(b.Selections, selection)
+	b.Odds = append(b.Odds, odds)
+}
+
+// Margin is the overround of a book and the fair odds of its selections under
+// every de-vigging method
+type Margin struct {
+	Book
+	Overround float64
+	Fair      map[Method][]float64 // Fair odds by method, in selection order; nil when Err is set
+	Err       error                // Why the book cannot be de-vigged
+}
+
+// NewMargin de-vigs a book with every method
+func NewMargin(book Book) Margin {
+	margin := Margin{Book: book, Overround: Overround(book.Odds), Fair: make(map[Method][]float64)}
+	for _, method := range Methods {
+		fair, err := DevigOdds(book.Odds, method)
+		if err != nil {
+			return Margin{Book: book, Overround: margin.Overround, Err: err}
+		}
+		margin.Fair[method] = fair
+	}
+	return margin
+}
+
+// FairOdds returns the fair odds of every selection under a method, keyed by selection
+func (m Margin) FairOdds(method Method) map[string]float64 {
+	fair := make(map[string]float64)
+	for i, selection := range m.Selections {
+		if odds := m.Fair[method]; odds != nil {
+			fair[selection] = odds[i]
+		}
+	}
+	return fair
+}
+
+// PrintMargins prints the overround of every book and the fair odds of its
+// selections next to the quoted odds
+func PrintMargins(margins []Margin) {
+	if len(margins) == 0 {
+		return
+	}
+
+	fmt.Println("\n===================== MARKET MARGINS =====================")
+	for _, margin := range margins {
+		fmt.Printf("\n%s: overround %.2f%% (margin %+.2f%%)\n", margin.Market, margin.Overround*100, (margin.Overround-1)*100)
+		if margin.Err != nil {
+			fmt.Printf("  Fair odds unavailable: %v\n", margin.Err)
+			continue
+		}
+
+		header := fmt.Sprintf("  %-40s %8s", "Selection", "Quoted")
+		for _, method := range Methods {
+			header += fmt.Sprintf(" %12s", method)
+		}
+		fmt.Println(header)
+		for i, selection := range margin.Selections {
+			row := fmt.Sprintf("  %-40s %8.2f", report.Truncate(selection, 40), margin.Odds[i])
+			for _, method := range Methods {
+				row += fmt.Sprintf(" %12.2f", margin.Fair[method][i])
+			}
+			fmt.Println(row)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package pricing
+
+import "math"
+
+// SetScoreQuote is the quoted price of one correct set score
+type SetScoreQuote struct {
+	Home int // Sets won by the home team
+	Away int
+	Odds float64
+}
+
+// SetScore is the margin-free probability of one correct set score
+type SetScore struct {
+	Home        int
+	Away        int
+	Probability float64
+}
+
+// Winner returns "1" or "2" for the team that wins the match with this score
+func (s SetScore) Winner() string {
+	if s.Home > s.Away {
+		return "1"
+	}
+	return "2"
+}
+
+// Sets returns the sets won by team ("1" or "2")
+func (s SetScore) Sets(team string) int {
+	if team == "2" {
+		return s.Away
+	}
+	return s.Home
+}
+
+// Margin returns the set margin of a team, negative when it loses
+func (s SetScore) Margin(team string) int {
+	if team == "2" {
+		return s.Away - s.Home
+	}
+	return s.Home - s.Away
+}
+
+// SetScoreDistribution is the probability of every correct set score of a match
+type SetScoreDistribution []SetScore
+
+// NewSetScoreDistribution removes the margin from correct set score quotes by
+// scaling their implied probabilities to sum to 1. Quotes without valid odds
+// are skipped.
+func NewSetScoreDistribution(quotes []SetScoreQuote) SetScoreDistribution {
+	distribution := SetScoreDistribution{}
+	total := 0.0
+	for _, quote := range quotes {
+		if quote.Odds <= 1 || quote.Home == quote.Away {
+			continue
+		}
+		distribution = append(distribution, SetScore{Home: quote.Home, Away: quote.Away, Probability: 1 / quote.Odds})
+		total += 1 / quote.Odds
+	}
+	for i := range distribution {
+		distribution[i].Probability /= total
+	}
+	return distribution
+}
+
+// Probability sums the probability of the set scores for which the event holds
+func (d SetScoreDistribution) Probability(event func(score SetScore) bool) float64 {
+	probability := 0.0
+	for _, score := range d {
+		if event(score) {
+			probability += score.Probability
+		}
+	}
+	return probability
+}
+
+// Winner is the probability that team ("1" or "2") wins the match
+func (d SetScoreDistribution) Winner(team string) float64 {
+	return d.Probability(func(score SetScore) bool { return score.Winner() == team })
+}
+
+// WinASet is the probability that team wins at least one set
+func (d SetScoreDistribution) WinASet(team string) float64 {
+	return d.Probability(func(score SetScore) bool { return score.Sets(team) > 0 })
+}
+
+// DecidingSet is the probability that the match goes to its deciding set
+func (d SetScoreDistribution) DecidingSet() float64 {
+	return d.Probability(func(score SetScore) bool {
+		return score.Home-score.Away == 1 || score.Away-score.Home == 1
+	})
+}
+
+// SetHandicap returns the probabilities that team wins and pushes with a set
+// handicap, e.g. -1.5
+func (d SetScoreDistribution) SetHandicap(team string, line float64) (win, push float64) {
+	win = d.Probability(func(score SetScore) bool { return float64(score.Margin(team))+line > 0 })
+	push = d.Probability(func(score SetScore) bool { return float64(score.Margin(team))+line == 0 })
+	return win, push
+}
+
+// TotalSets returns the probabilities that an over (or under) bet on the
+// number of sets played wins and pushes
+func (d SetScoreDistribution) TotalSets(over bool, line float64) (win, push float64) {
+	win = d.Probability(func(score SetScore) bool {
+		sets := float64(score.Home + score.Away)
+		return (over && sets > line) || (!over && sets < line)
+	})
+	push = d.Probability(func(score SetScore) bool { return float64(score.Home+score.Away) == line })
+	return win, push
+}
+
+// FairOdds is the decimal price with no margin for a bet that wins with
+// probability win and returns the stake with probability push. It is 0 when
+// the bet cannot win.
+func FairOdds(win, push float64) float64 {
+	if win <= 0 {
+		return 0
+	}
+	return (1 - push) / win
+}
+
+// Edge is the expected profit per unit staked at the offered odds, leaving
+// pushes aside; a positive edge means the offered price beats the fair one
+func Edge(offered, fair float64) float64 {
+	if fair <= 0 {
+		return math.Inf(-1)
+	}
+	return offered/fair - 1
+}
@@ -0,0 +1,201 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+)
+
+// book is a six-outcome correct set score book with a 5% overround: the
+// implied probabilities 0.25, 0.25, 0.2, 0.125, 0.125 and 0.1 sum to 1.05
+func book() SetScoreDistribution {
+	return NewSetScoreDistribution([]SetScoreQuote{
+		{Home: 3, Away: 0, Odds: 4},
+		{Home: 3, Away: 1, Odds: 4},
+		{Home: 3, Away: 2, Odds: 5},
+		{Home: 2, Away: 3, Odds: 8},
+		{Home: 1, Away: 3, Odds: 8},
+		{Home: 0, Away: 3, Odds: 10},
+	})
+}
+
+func TestNewSetScoreDistribution(t *testing.T) {
+	tests := []struct {
+		name   string
+		quotes []SetScoreQuote
+		want   []SetScore
+	}{
+		{"six-outcome book", []SetScoreQuote{
+			{Home: 3, Away: 0, Odds: 4}, {Home: 3, Away: 1, Odds: 4}, {Home: 3, Away: 2, Odds: 5},
+			{Home: 2, Away: 3, Odds: 8}, {Home: 1, Away: 3, Odds: 8}, {Home: 0, Away: 3, Odds: 10},
+		}, []SetScore{
+			{3, 0, 0.2381}, {3, 1, 0.2381}, {3, 2, 0.1905}, {2, 3, 0.1190}, {1, 3, 0.1190}, {0, 3, 0.0952},
+		}},
+		{"book without margin", []SetScoreQuote{{Home: 3, Away: 0, Odds: 2}, {Home: 0, Away: 3, Odds: 2}},
+			[]SetScore{{3, 0, 0.5}, {0, 3, 0.5}}},
+		{"invalid odds and drawn scores skipped", []SetScoreQuote{
+			{Home: 3, Away: 1, Odds: 2}, {Home: 1, Away: 3, Odds: 4}, {Home: 3, Away: 2, Odds: 1},
+			{Home: 2, Away: 3, Odds: 0}, {Home: 2, Away: 2, Odds: 3},
+		}, []SetScore{{3, 1, 0.6667}, {1, 3, 0.3333}}},
+		{"no quotes", nil, []SetScore{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NewSetScoreDistribution(test.quotes)
+			if len(got) != len(test.want) {
+				t.Fatalf("NewSetScoreDistribution = %v, want %v", got, test.want)
+			}
+			total := 0.0
+			for i, score := range got {
+				total += score.Probability
+				want := test.want[i]
+				if score.Home != want.Home || score.Away != want.Away || math.Abs(score.Probability-want.Probability) > 1e-4 {
+					t.Errorf("score %d = %d-%d %.4f, want %d-%d %.4f", i, score.Home, score.Away, score.Probability,
+						want.Home, want.Away, want.Probability)
+				}
+			}
+			if len(got) > 0 && math.Abs(total-1) > 1e-9 {
+				t.Errorf("probabilities sum to %v, want 1", total)
+			}
+		})
+	}
+}
+
+func TestSetScoreMarkets(t *testing.T) {
+	distribution := book()
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"home winner", distribution.Winner("1"), 0.6667},
+		{"away winner", distribution.Winner("2"), 0.3333},
+		{"home wins a set", distribution.WinASet("1"), 0.9048},
+		{"away wins a set", distribution.WinASet("2"), 0.7619},
+		{"deciding set", distribution.DecidingSet(), 0.3095},
+	}
+
+	for _, test := range tests {
+		if math.Abs(test.got-test.want) > 1e-4 {
+			t.Errorf("%s = %.4f, want %.4f", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestSetHandicap(t *testing.T) {
+	distribution := book()
+	tests := []struct {
+		team      string
+		line      float64
+		win, push float64
+	}{
+		{"1", -2.5, 0.2381, 0},
+		{"1", -1.5, 0.4762, 0},
+		{"1", 1.5, 0.7857, 0},
+		{"1", 2.5, 0.9048, 0},
+		{"2", -1.5, 0.2143, 0},
+		{"2", 1.5, 0.5238, 0},
+		{"1", -1, 0.4762, 0.1905},
+	}
+
+	for _, test := range tests {
+		win, push := distribution.SetHandicap(test.team, test.line)
+		if math.Abs(win-test.win) > 1e-4 || math.Abs(push-test.push) > 1e-4 {
+			t.Errorf("SetHandicap(%s, %+.1f) = %.4f, %.4f, want %.4f, %.4f", test.team, test.line, win, push, test.win, test.push)
+		}
+	}
+
+	// A team giving a half line wins exactly when the other team getting it loses
+	for _, line := range []float64{1.5, 2.5} {
+		for _, team := range []string{"1", "2"} {
+			other := "2"
+			if team == "2" {
+				other = "1"
+			}
+			giving, _ := distribution.SetHandicap(team, -line)
+			getting, _ := distribution.SetHandicap(other, line)
+			if math.Abs(giving+getting-1) > 1e-9 {
+				t.Errorf("SetHandicap(%s, -%.1f) + SetHandicap(%s, +%.1f) = %v, want 1", team, line, other, line, giving+getting)
+			}
+		}
+	}
+}
+
+func TestTotalSets(t *testing.T) {
+	distribution := book()
+	tests := []struct {
+		over      bool
+		line      float64
+		win, push float64
+	}{
+		{true, 3.5, 0.6667, 0},
+		{false, 3.5, 0.3333, 0},
+		{true, 4.5, 0.3095, 0},
+		{false, 4.5, 0.6905, 0},
+		{true, 4, 0.3095, 0.3571},
+		{false, 4, 0.3333, 0.3571},
+	}
+
+	for _, test := range tests {
+		win, push := distribution.TotalSets(test.over, test.line)
+		if math.Abs(win-test.win) > 1e-4 || math.Abs(push-test.push) > 1e-4 {
+			t.Errorf("TotalSets(%t, %.1f) = %.4f, %.4f, want %.4f, %.4f", test.over, test.line, win, push, test.win, test.push)
+		}
+	}
+
+	// Over and under a half line cover every score between them
+	for _, line := range []float64{3.5, 4.5} {
+		over, _ := distribution.TotalSets(true, line)
+		under, _ := distribution.TotalSets(false, line)
+		if math.Abs(over+under-1) > 1e-9 {
+			t.Errorf("TotalSets over + under %.1f = %v, want 1", line, over+under)
+		}
+	}
+}
+
+func TestFairOdds(t *testing.T) {
+	tests := []struct {
+		win, push float64
+		want      float64
+	}{
+		{0.5, 0, 2},
+		{0.25, 0, 4},
+		{1, 0, 1},
+		{0.25, 0.5, 2},
+		{0.4762, 0.1905, 1.7},
+		{0, 0.3, 0},
+		{0, 0, 0},
+	}
+
+	for _, test := range tests {
+		if got := FairOdds(test.win, test.push); math.Abs(got-test.want) > 1e-3 {
+			t.Errorf("FairOdds(%v, %v) = %.4f, want %.4f", test.win, test.push, got, test.want)
+		}
+	}
+}
+
+func TestEdge(t *testing.T) {
+	tests := []struct {
+		offered, fair float64
+		want          float64
+	}{
+		{2.1, 2, 0.05},
+		{1.9, 2, -0.05},
+		{2, 2, 0},
+		{1.44, 1.52, -0.0526},
+		{2, 0, math.Inf(-1)},
+	}
+
+	for _, test := range tests {
+		got := Edge(test.offered, test.fair)
+		if math.IsInf(test.want, -1) {
+			if !math.IsInf(got, -1) {
+				t.Errorf("Edge(%v, %v) = %v, want -Inf", test.offered, test.fair, got)
+			}
+			continue
+		}
+		if math.Abs(got-test.want) > 1e-4 {
+			t.Errorf("Edge(%v, %v) = %.4f, want %.4f", test.offered, test.fair, got, test.want)
+		}
+	}
+}
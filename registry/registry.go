@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"fmt"
+)
+
+// MarketProcessor extracts the selections of a single Bet365 market from
+// prematch data and settles them against a match result.
+//
+// P is the prematch input, R the result input, S the selection type and E the
+// evaluation type of the sport the processor belongs to.
+type MarketProcessor[P, R, S, E any] interface {
+	// MarketID returns the Bet365 market ID the processor is registered under
+	MarketID() string
+	// Process extracts the selections offered for the market
+	Process(prematch P) []S
+	// Evaluate settles a selection of the market against the result
+	Evaluate(selection S, result R) E
+}
+
+// Registry holds the market processors of a sport keyed by Bet365 market ID
+type Registry[P, R, S, E any] struct {
+	processors map[string]MarketProcessor[P, R, S, E]
+	order      []string
+}
+
+// New creates an empty market registry
+func New[P, R, S, E any]() *Registry[P, R, S, E] {
+	return &Registry[P, R, S, E]{
+		processors: make(map[string]MarketProcessor[P, R, S, E]),
+	}
+}
+
+// Register adds a processor to the registry. It panics if a processor is
+// already registered for the same market ID, since that is a programming error.
+func (r *Registry[P, R, S, E]) Register(processor MarketProcessor[P, R, S, E]) {
+	id := processor.MarketID()
+	if _, exists := r.processors[id]; exists {
+		panic(fmt.Sprintf("registry: market %s registered twice", id))
+	}
+	r.processors[id] = processor
+	r.order = append(r.order, id)
+}
+
+// Lookup returns the processor registered for a market ID
+func (r *Registry[P, R, S, E]) Lookup(marketID string) (MarketProcessor[P, R, S, E], bool) {
+	processor, ok := r.processors[marketID]
+	return processor, ok
+}
+
+// Processors returns all registered processors in registration order
+func (r *Registry[P, R, S, E]) Processors() []MarketProcessor[P, R, S, E] {
+	processors := make([]MarketProcessor[P, R, S, E], 0, len(r.order))
+	for _, id := range r.order {
+		processors = append(processors, r.processors[id])
+	}
+	return processors
+}
+
+// Process runs every registered processor against the prematch data and
+// returns the selections in registration order
+func (r *Registry[P, R, S, E]) Process(prematch P) []S {
+	selections := []S{}
+	for _, processor := range r.Processors() {
+		selections = append(selections, processor.Process(prematch)...)
+	}
+	return selections
+}
+
+// Evaluate settles a selection with the processor registered for its market
+func (r *Registry[P, R, S, E]) Evaluate(marketID string, selection S, result R) (E, error) {
+	processor, ok := r.processors[marketID]
+	if !ok {
+		var empty E
+		return empty, fmt.Errorf("no processor registered for market %s", marketID)
+	}
+	return processor.Evaluate(selection, result), nil
+}
@@ -0,0 +1,12 @@
+// Package report holds the text helpers shared by the printed reports
+package report
+
+import "strings"
+
+// Truncate shortens text to at most n characters, ending it with "..." when cut
+func Truncate(text string, n int) string {
+	if len([]rune(text)) <= n {
+		return text
+	}
+	return strings.TrimSpace(string([]rune(text)[:n-3])) + "..."
+}
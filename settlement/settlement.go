@@ -0,0 +1,219 @@
+package settlement
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+)
+
+// Status is the settled state of a bet
+type Status string
+
+// Settlement statuses
+const (
+	Win      Status = "WIN"
+	Lose     Status = "LOSE"
+	Void     Status = "VOID"      // Stake returned, e.g. the market could not be settled
+	Push     Status = "PUSH"      // Stake returned because the result landed on the line
+	HalfWin  Status = "HALF WIN"  // Half the stake won, the other half pushed
+	HalfLose Status = "HALF LOSE" // Half the stake lost, the other half pushed
+	DeadHeat Status = "DEAD HEAT" // A share of the stake won at full odds, the rest lost
+)
+
+// Statuses lists every status in report order
+var Statuses = []Status{Win, HalfWin, DeadHeat, Push, Void, HalfLose, Lose}
+
+// Outcome is the settlement of a single bet
+type Outcome struct {
+	Status Status
+	Places int // Places paid in a dead heat, e.g. 1 for two winners tied for first
+	Tied   int // Selections tied for the places in a dead heat
+}
+
+// Share is the part of the stake settled as a winner in a dead heat, e.g. 1/2
+// for two winners tied for one place
+func (o Outcome) Share() *big.Rat {
+	if o.Status != DeadHeat || o.Tied <= 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(int64(o.Places), int64(o.Tied))
+}
+
+// WinOrLose settles a bet that can only win or lose
+func WinOrLose(won bool) Outcome {
+	if won {
+		return Outcome{Status: Win}
+	}
+	return Outcome{Status: Lose}
+}
+
+// Line settles a bet on a line from its margin: the result minus the line for
+// an Over, or the handicapped score difference for a handicap. A zero margin
+// is a push, which only happens on whole-number lines.
+func Line(margin float64) Outcome {
+	switch {
+	case margin > 0:
+		return Outcome{Status: Win}
+	case margin < 0:
+		return Outcome{Status: Lose}
+	}
+	return Outcome{Status: Push}
+}
+
+// Split returns the lines a stake on line is divided across. A quarter line
+// splits the stake equally between its two neighbouring lines, e.g. -1.25
+// into -1.5 and -1; any other line is returned on its own.
+func Split(line float64) []float64 {
+	quarters := math.Round(line * 4)
+	if math.Mod(quarters, 2) == 0 {
+		return []float64{line}
+	}
+	return []float64{(quarters - 1) / 4, (quarters + 1) / 4}
+}
+
+// Combine settles a stake split in two equal halves from the outcome of each half
+func Combine(first, second Outcome) Outcome {
+	if first.Status == second.Status {
+		return first
+	}
+	if first.Refunded() {
+		first, second = second, first
+	}
+	switch {
+	case second.Refunded() && first.Status == Win:
+		return Outcome{Status: HalfWin}
+	case second.Refunded() && first.Status == Lose:
+		return Outcome{Status: HalfLose}
+	case second.Refunded():
+		return first
+	}
+	// One half won and the other lost: half the stake is paid at full odds
+	return Outcome{Status: DeadHeat, Places: 1, Tied: 2}
+}
+
+// SplitLine settles a bet on a line that may be a quarter line. margin
+// returns the margin of the bet on one of the lines the stake is split
+// across, as passed to Line.
+func SplitLine(line float64, margin func(line float64) float64) Outcome {
+	lines := Split(line)
+	outcome := Line(margin(lines[0]))
+	if len(lines) == 2 {
+		outcome = Combine(outcome, Line(margin(lines[1])))
+	}
+	return outcome
+}
+
+// DeadHeatFor settles a winning selection that tied with others for places paid
+// places. With a single winner it is a plain win.
+func DeadHeatFor(tied, places int) Outcome {
+	if tied <= places {
+		return Outcome{Status: Win}
+	}
+	return Outcome{Status: DeadHeat, Places: places, Tied: tied}
+}
+
+// ReturnPerUnit is the exact amount paid back per unit staked at the given
+// price, e.g. 11/6 for a win at 5/6
+func (o Outcome) ReturnPerUnit(price odds.Odds) *big.Rat {
+	num, den := price.Profit()
+	profit := new(big.Rat)
+	if den != 0 {
+		profit.SetFrac64(num, den)
+	}
+	won := new(big.Rat).Add(profit, big.NewRat(1, 1))
+	half := big.NewRat(1, 2)
+	switch o.Status {
+	case Win:
+		return won
+	case Void, Push:
+		return big.NewRat(1, 1)
+	case HalfWin:
+		return won.Add(won, big.NewRat(1, 1)).Mul(won, half)
+	case HalfLose:
+		return half
+	case DeadHeat:
+		return won.Mul(won, o.Share())
+	}
+	return new(big.Rat)
+}
+
+// Return is the amount paid back on a stake at the given price, computed
+// from the exact fraction rather than the rounded decimal odds and rounded
+// to the minor unit with the default rounding
+func (o Outcome) Return(stake money.Money, price odds.Odds) money.Money {
+	return stake.Mul(o.ReturnPerUnit(price), money.DefaultRounding)
+}
+
+// IsWin reports whether any part of the stake won
+func (o Outcome) IsWin() bool {
+	return o.Status == Win || o.Status == HalfWin || o.Status == DeadHeat
+}
+
+// Refunded reports whether the whole stake is returned
+func (o Outcome) Refunded() bool {
+	return o.Status == Void || o.Status == Push
+}
+
+// String describes the outcome, including the dead-heat reduction
+func (o Outcome) String() string {
+	if o.Status == DeadHeat {
+		share, _ := o.Share().Float64()
+		return fmt.Sprintf("%s (%.0f%% of stake paid at full odds)", o.Status, share*100)
+	}
+	return string(o.Status)
+}
+
+// Summary totals the stakes and returns of settled bets
+type Summary struct {
+	Bets    int
+	Counts  map[Status]int
+	Stake   money.Money // Total staked
+	Returns money.Money // Total paid back, refunded stakes included
+	Settled money.Money // Stake on bets that were not refunded
+}
+
+// NewSummary creates an empty summary
+func NewSummary() Summary {
+	return Summary{Counts: make(map[Status]int)}
+}
+
+// Add records a settled bet and the amount it paid back
+func (s *Summary) Add(outcome Outcome, stake, returned money.Money) {
+	s.Bets++
+	s.Counts[outcome.Status]++
+	s.Stake = s.Stake.Add(stake)
+	s.Returns = s.Returns.Add(returned)
+	if !outcome.Refunded() {
+		s.Settled = s.Settled.Add(stake)
+	}
+}
+
+// Winners counts the bets that won at least part of their stake
+func (s Summary) Winners() int {
+	return s.Counts[Win] + s.Counts[HalfWin] + s.Counts[DeadHeat]
+}
+
+// ProfitLoss is the total returned minus the total staked
+func (s Summary) ProfitLoss() money.Money {
+	return s.Returns.Sub(s.Stake)
+}
+
+// ROI is the profit or loss as a percentage of the stake on bets that were not refunded
+func (s Summary) ROI() float64 {
+	return s.ProfitLoss().Ratio(s.Settled) * 100
+}
+
+// Breakdown lists the number of bets per status, e.g. "WIN 3 | PUSH 1 | LOSE 2"
+func (s Summary) Breakdown() string {
+	parts := []string{}
+	for _, status := range Statuses {
+		if count := s.Counts[status]; count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", status, count))
+		}
+	}
+	return strings.Join(parts, " | ")
+}
@@ -0,0 +1,43 @@
+package settlement
+
+import (
+	"testing"
+
+	"github.com/yesetoda/bet365-evaluator-go/money"
+	"github.com/yesetoda/bet365-evaluator-go/odds"
+)
+
+func TestDeadHeatReturn(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome Outcome
+		stake   string
+		num     int64
+		den     int64
+		want    string
+	}{
+		{"three-way tie at evens", DeadHeatFor(3, 1), "3", 1, 1, "$2.00"},
+		{"three-way tie at evens, larger stake", DeadHeatFor(3, 1), "30", 1, 1, "$20.00"},
+		{"three-way tie at 2/1", DeadHeatFor(3, 1), "10", 2, 1, "$10.00"},
+		{"two tied for two of three places", DeadHeatFor(3, 2), "9", 1, 2, "$9.00"},
+		{"quarter line split", Combine(WinOrLose(true), WinOrLose(false)), "10", 5, 6, "$9.16"},
+		{"single winner", DeadHeatFor(1, 1), "10", 5, 6, "$18.33"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stake, err := money.Parse(test.stake)
+			if err != nil {
+				t.Fatalf("money.Parse(%q): %v", test.stake, err)
+			}
+			price, err := odds.New(test.num, test.den)
+			if err != nil {
+				t.Fatalf("odds.New(%d, %d): %v", test.num, test.den, err)
+			}
+			if got := test.outcome.Return(stake, price).String(); got != test.want {
+				t.Errorf("%s on %s at %d/%d returned %s, want %s",
+					test.outcome, test.stake, test.num, test.den, got, test.want)
+			}
+		})
+	}
+}
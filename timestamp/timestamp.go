@@ -0,0 +1,120 @@
+package timestamp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	// Embedded so zones load on machines without a time zone database
+	_ "time/tzdata"
+)
+
+// Layout is how reports show a time, e.g. "Thursday, May 1, 2025 19:30 IST"
+const Layout = "Monday, January 2, 2006 15:04 MST"
+
+// ZoneEnv is the environment variable naming the IANA time zone reports are
+// shown in, e.g. "Europe/London"; reports are in UTC when it is not set
+const ZoneEnv = "REPORT_TIMEZONE"
+
+// badZones remembers the zones already reported as unknown, so each is logged once
+var badZones sync.Map
+
+// Location returns the location reports are shown in, read from ZoneEnv. An
+// unknown zone is logged once and falls back to UTC.
+func Location() *time.Location {
+	name := strings.TrimSpace(os.Getenv(ZoneEnv))
+	if name == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		if _, logged := badZones.LoadOrStore(name, true); !logged {
+			log.Printf("Unknown time zone %q, showing times in UTC: %v", name, err)
+		}
+		return time.UTC
+	}
+	return location
+}
+
+// Timestamp is a moment the feed sends as unix seconds in a string, e.g.
+// "1746122977". The zero value is a time the feed left out.
+type Timestamp struct {
+	time.Time
+}
+
+// Unix returns the timestamp of unix seconds
+func Unix(seconds int64) Timestamp {
+	return Timestamp{time.Unix(seconds, 0).UTC()}
+}
+
+// Parse reads unix seconds or an RFC 3339 time; an empty string or "0" is the zero timestamp
+func Parse(text string) (Timestamp, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || text == "0" {
+		return Timestamp{}, nil
+	}
+	if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return Unix(seconds), nil
+	}
+	parsed, err := time.Parse(time.RFC3339, text)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("invalid timestamp %q", text)
+	}
+	return Timestamp{parsed.UTC()}, nil
+}
+
+// InZone returns the time in the report zone
+func (t Timestamp) InZone() time.Time {
+	return t.Time.In(Location())
+}
+
+// Display prints the time in the report zone, or "unavailable" when the feed
+// left it out
+func (t Timestamp) Display() string {
+	if t.IsZero() {
+		return "unavailable"
+	}
+	return t.InZone().Format(Layout)
+}
+
+// String prints the time in UTC, or "" when the feed left it out
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.UTC().Format(Layout)
+}
+
+// UnmarshalJSON reads a timestamp from a string or a number of unix seconds,
+// or from an RFC 3339 string; null, "" and 0 are the zero timestamp
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid timestamp %s", data)
+		}
+		text = number.String()
+	}
+	parsed, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// MarshalJSON writes the timestamp as the feed sends it, e.g. "1746122977",
+// or "" when it was left out
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return json.Marshal("")
+	}
+	return json.Marshal(strconv.FormatInt(t.Unix(), 10))
+}
@@ -0,0 +1,85 @@
+package timestamp
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		want string
+	}{
+		{"unset", "", "UTC"},
+		{"blank", "  ", "UTC"},
+		{"IANA zone", "Europe/London", "Europe/London"},
+		{"surrounding spaces", " Asia/Kolkata ", "Asia/Kolkata"},
+		{"explicit UTC", "UTC", "UTC"},
+		{"unknown zone", "Mars/Olympus_Mons", "UTC"},
+		{"country code", "in", "UTC"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(ZoneEnv, test.env)
+			if got := Location().String(); got != test.want {
+				t.Errorf("Location() with %s=%q = %s, want %s", ZoneEnv, test.env, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDisplay(t *testing.T) {
+	tests := []struct {
+		name string
+		env  string
+		time Timestamp
+		want string
+	}{
+		{"UTC", "", Unix(1746122977), "Thursday, May 1, 2025 18:09 UTC"},
+		{"report zone", "Asia/Kolkata", Unix(1746122977), "Thursday, May 1, 2025 23:39 IST"},
+		{"unknown zone falls back to UTC", "Nowhere", Unix(1746122977), "Thursday, May 1, 2025 18:09 UTC"},
+		{"left out", "Asia/Kolkata", Timestamp{}, "unavailable"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv(ZoneEnv, test.env)
+			if got := test.time.Display(); got != test.want {
+				t.Errorf("Display() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		data    string
+		want    time.Time
+		wantErr bool
+	}{
+		{`"1746122977"`, time.Unix(1746122977, 0), false},
+		{`1746122977`, time.Unix(1746122977, 0), false},
+		{`"2025-05-01T18:09:37Z"`, time.Unix(1746122977, 0), false},
+		{`""`, time.Time{}, false},
+		{`"0"`, time.Time{}, false},
+		{`null`, time.Time{}, false},
+		{`"yesterday"`, time.Time{}, true},
+		{`true`, time.Time{}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.data, func(t *testing.T) {
+			var got Timestamp
+			err := json.Unmarshal([]byte(test.data), &got)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %t", test.data, err, test.wantErr)
+			}
+			if !got.Time.Equal(test.want) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", test.data, got.Time, test.want)
+			}
+		})
+	}
+}